@@ -0,0 +1,97 @@
+package bitcoin
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// version bytes of the base58check encodings of the main and test networks
+const (
+	MainNetP2PKH byte = 0x00
+	TestNetP2PKH byte = 0x6f
+	MainNetWIF   byte = 0x80
+	TestNetWIF   byte = 0xef
+)
+
+// ErrInvalidAddress is returned if the address isn't a P2PKH one
+var ErrInvalidAddress = errors.New("bitcoin: invalid P2PKH address")
+
+// Hash160 computes RIPEMD160(SHA256(data))
+func Hash160(data []byte) []byte {
+	h := sha256.Sum256(data)
+
+	r := ripemd160.New()
+	r.Write(h[:])
+
+	return r.Sum(nil)
+}
+
+// serializePublicKey encodes the public key in the compressed or
+// uncompressed form
+func serializePublicKey(pub *ecdsa.PublicKey, compressed bool) ([]byte, error) {
+	if compressed {
+		return pub.Compress()
+	}
+
+	return pub.UncompressedEncode()
+}
+
+// P2PKHAddress derives the pay-to-public-key-hash address of the public key
+// in the compressed or uncompressed form, e.g., with the version byte
+// MainNetP2PKH or TestNetP2PKH
+func P2PKHAddress(pub *ecdsa.PublicKey, compressed bool, version byte) (string, error) {
+	data, err := serializePublicKey(pub, compressed)
+	if nil != err {
+		return "", err
+	}
+
+	return Base58CheckEncode(version, Hash160(data)), nil
+}
+
+// decodeP2PKHAddress extracts the public key hash from a P2PKH address of
+// the main or test network
+func decodeP2PKHAddress(address string) ([]byte, error) {
+	version, hash, err := Base58CheckDecode(address)
+	if nil != err {
+		return nil, err
+	}
+
+	if ((MainNetP2PKH != version) && (TestNetP2PKH != version)) ||
+		(ripemd160.Size != len(hash)) {
+		return nil, ErrInvalidAddress
+	}
+
+	return hash, nil
+}
+
+// DecodeWIF decodes the private key over secp256k1 in the wallet import
+// format, along with whether its public key goes in the compressed form
+func DecodeWIF(wif string) (priv *ecdsa.PrivateKey, compressed bool, err error) {
+	version, payload, err := Base58CheckDecode(wif)
+	if nil != err {
+		return nil, false, err
+	}
+	if (MainNetWIF != version) && (TestNetWIF != version) {
+		return nil, false, errors.New("bitcoin: invalid WIF version")
+	}
+
+	switch {
+	case 32 == len(payload):
+	case (33 == len(payload)) && (0x01 == payload[32]):
+		compressed = true
+	default:
+		return nil, false, errors.New("bitcoin: invalid WIF length")
+	}
+
+	priv, err = ecdsa.NewPrivateKey(elliptic.P256k1(), new(big.Int).SetBytes(payload[:32]))
+	if nil != err {
+		return nil, false, err
+	}
+
+	return priv, compressed, nil
+}
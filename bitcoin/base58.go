@@ -0,0 +1,99 @@
+package bitcoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+// base58Alphabet is the alphabet of the base58 encoding used by bitcoin
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var (
+	// ErrInvalidBase58 is returned if the string has characters out of the
+	// base58 alphabet
+	ErrInvalidBase58 = errors.New("bitcoin: invalid base58 string")
+	// ErrChecksum is returned if the checksum of a base58check string
+	// mismatches
+	ErrChecksum = errors.New("bitcoin: invalid checksum")
+)
+
+// base58Encode encodes data into base58, where each leading zero byte
+// becomes a leading '1'
+func base58Encode(data []byte) string {
+	x := new(big.Int).SetBytes(data)
+	radix, mod := big.NewInt(58), new(big.Int)
+
+	out := make([]byte, 0, len(data)*138/100+1)
+	for 0 != x.Sign() {
+		x.DivMod(x, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range data {
+		if 0 != b {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return string(out)
+}
+
+// base58Decode reverses base58Encode
+func base58Decode(s string) ([]byte, error) {
+	x, radix := new(big.Int), big.NewInt(58)
+	for i := 0; i < len(s); i++ {
+		d := bytes.IndexByte([]byte(base58Alphabet), s[i])
+		if d < 0 {
+			return nil, ErrInvalidBase58
+		}
+		x.Mul(x, radix)
+		x.Add(x, big.NewInt(int64(d)))
+	}
+
+	var zeros int
+	for zeros < len(s) && base58Alphabet[0] == s[zeros] {
+		zeros++
+	}
+
+	return append(make([]byte, zeros), x.Bytes()...), nil
+}
+
+// checksum is the first 4 bytes of the double SHA-256 of data
+func checksum(data []byte) []byte {
+	h := sha256.Sum256(data)
+	h = sha256.Sum256(h[:])
+
+	return h[:4]
+}
+
+// Base58CheckEncode encodes the version byte followed by the payload with
+// a 4-byte checksum appended, as is done for addresses and WIF keys
+func Base58CheckEncode(version byte, payload []byte) string {
+	data := append([]byte{version}, payload...)
+
+	return base58Encode(append(data, checksum(data)...))
+}
+
+// Base58CheckDecode reverses Base58CheckEncode
+func Base58CheckDecode(s string) (version byte, payload []byte, err error) {
+	data, err := base58Decode(s)
+	if nil != err {
+		return 0, nil, err
+	}
+	if len(data) < 5 {
+		return 0, nil, ErrChecksum
+	}
+
+	n := len(data) - 4
+	if !bytes.Equal(checksum(data[:n]), data[n:]) {
+		return 0, nil, ErrChecksum
+	}
+
+	return data[0], data[1:n], nil
+}
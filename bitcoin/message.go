@@ -0,0 +1,105 @@
+package bitcoin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+)
+
+// messageMagic prefixes every message before hashing, so that a signed
+// message can never be a valid transaction
+const messageMagic = "Bitcoin Signed Message:\n"
+
+// writeVarString writes s prefixed by its length as a CompactSize varint
+func writeVarString(buf *bytes.Buffer, s []byte) {
+	var n [9]byte
+
+	switch l := uint64(len(s)); {
+	case l < 0xfd:
+		buf.WriteByte(byte(l))
+	case l <= 0xffff:
+		n[0] = 0xfd
+		binary.LittleEndian.PutUint16(n[1:], uint16(l))
+		buf.Write(n[:3])
+	case l <= 0xffffffff:
+		n[0] = 0xfe
+		binary.LittleEndian.PutUint32(n[1:], uint32(l))
+		buf.Write(n[:5])
+	default:
+		n[0] = 0xff
+		binary.LittleEndian.PutUint64(n[1:], l)
+		buf.Write(n[:])
+	}
+
+	buf.Write(s)
+}
+
+// MessageHash computes the double SHA-256 of the magic and the message, both
+// prefixed by their lengths as varints
+func MessageHash(msg []byte) []byte {
+	var buf bytes.Buffer
+	writeVarString(&buf, []byte(messageMagic))
+	writeVarString(&buf, msg)
+
+	h := sha256.Sum256(buf.Bytes())
+	h = sha256.Sum256(h[:])
+
+	return h[:]
+}
+
+// SignMessage signs the message as the signmessage RPC of bitcoin core does,
+// which outputs the base64 encoding of the 65-byte compact signature. The
+// compressed flag tells the form of the public key behind the address.
+func SignMessage(priv *ecdsa.PrivateKey, msg []byte, compressed bool) (string, error) {
+	sig, err := ecdsa.SignCompact(priv, MessageHash(msg), compressed)
+	if nil != err {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// RecoverMessagePublicKey recovers the public key from the base64 signature
+// of the message output by SignMessage, along with whether it goes in the
+// compressed form
+func RecoverMessagePublicKey(sig string, msg []byte) (*ecdsa.PublicKey, bool, error) {
+	data, err := base64.StdEncoding.DecodeString(sig)
+	if nil != err {
+		return nil, false, err
+	}
+
+	return ecdsa.RecoverCompact(elliptic.P256k1(), data, MessageHash(msg))
+}
+
+// VerifyMessage checks the base64 signature of the message against the
+// P2PKH address as the verifymessage RPC of bitcoin core does, i.e., the
+// public key recovered from the signature must hash to the address. An
+// error is returned if the address or the signature is malformed.
+func VerifyMessage(address, sig string, msg []byte) (bool, error) {
+	hash, err := decodeP2PKHAddress(address)
+	if nil != err {
+		return false, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(sig)
+	if nil != err {
+		return false, err
+	}
+
+	pub, compressed, err := ecdsa.RecoverCompact(elliptic.P256k1(), data, MessageHash(msg))
+	if nil != err {
+		// as bitcoin core does, no public key recovered means a bad signature
+		return false, nil
+	}
+
+	data, err = serializePublicKey(pub, compressed)
+	if nil != err {
+		return false, err
+	}
+
+	return bytes.Equal(Hash160(data), hash), nil
+}
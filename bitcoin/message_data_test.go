@@ -0,0 +1,52 @@
+package bitcoin_test
+
+// signMessageTestVec are the vectors of the message signing tests in
+// src/test/util_tests.cpp and test/functional/rpc_signmessage.py of bitcoin
+// core
+var signMessageTestVec = []struct {
+	wif     string
+	address string
+	msg     string
+	sig     string
+}{
+	{
+		"cUeKHd5orzT3mz8P9pxyREHfsWtVfgsfDjiZZBcjUBAaGk1BTj7N",
+		"mpLQjfK79b7CCV4VMJWEWAj5Mpx8Up5zxB",
+		"This is just a test message",
+		"INbVnW4e6PeRmsv2Qgu8NuopvrVjkcxob+sX8OcZG0SALhWybUjzMLPdAsXI46YZGb0KQTRii+wWIQzRpG/U+S0=",
+	},
+}
+
+// verifyMessageTestVec are the vectors of the MessageVerify tests in
+// src/test/util_tests.cpp of bitcoin core
+var verifyMessageTestVec = []struct {
+	address string
+	sig     string
+	msg     string
+	isValid bool
+}{
+	{
+		"15CRxFdyRpGZLW9w8HnHvVduizdL5jKNbs",
+		"IPojfrX2dfPnH26UegfbGQQLrdK844DlHq5157/P6h57WyuS/Qsl+h/WSVGDF4MUi4rWSswW38oimDYfNNUBUOk=",
+		"Trust no one",
+		true,
+	},
+	{
+		"11canuhp9X2NocwCq7xNrQYTmUgZAnLK3",
+		"IIcaIENoYW5jZWxsb3Igb24gYnJpbmsgb2Ygc2Vjb25kIGJhaWxvdXQgZm9yIGJhbmtzIAaHRtbCeDZINyavx14=",
+		"Trust me",
+		true,
+	},
+	{
+		"15CRxFdyRpGZLW9w8HnHvVduizdL5jKNbs",
+		"IPojfrX2dfPnH26UegfbGQQLrdK844DlHq5157/P6h57WyuS/Qsl+h/WSVGDF4MUi4rWSswW38oimDYfNNUBUOk=",
+		"I never signed this",
+		false,
+	},
+	{
+		"11canuhp9X2NocwCq7xNrQYTmUgZAnLK3",
+		"IPojfrX2dfPnH26UegfbGQQLrdK844DlHq5157/P6h57WyuS/Qsl+h/WSVGDF4MUi4rWSswW38oimDYfNNUBUOk=",
+		"Trust no one",
+		false,
+	},
+}
@@ -0,0 +1,154 @@
+package bitcoin_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/sammy00/crypto/bitcoin"
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+)
+
+func TestSignMessage(t *testing.T) {
+	for i, c := range signMessageTestVec {
+		priv, compressed, err := bitcoin.DecodeWIF(c.wif)
+		if nil != err {
+			t.Fatalf("#%d: %v", i, err)
+		}
+
+		address, err := bitcoin.P2PKHAddress(&priv.PublicKey, compressed, bitcoin.TestNetP2PKH)
+		if nil != err {
+			t.Fatalf("#%d: %v", i, err)
+		} else if address != c.address {
+			t.Fatalf("#%d: invalid address: got %s, want %s", i, address, c.address)
+		}
+
+		sig, err := bitcoin.SignMessage(priv, []byte(c.msg), compressed)
+		if nil != err {
+			t.Fatalf("#%d: %v", i, err)
+		} else if sig != c.sig {
+			t.Fatalf("#%d: invalid signature: got %s, want %s", i, sig, c.sig)
+		}
+
+		if ok, err := bitcoin.VerifyMessage(c.address, sig, []byte(c.msg)); nil != err {
+			t.Fatalf("#%d: %v", i, err)
+		} else if !ok {
+			t.Fatalf("#%d: the signature should be valid", i)
+		}
+	}
+}
+
+func TestVerifyMessage(t *testing.T) {
+	for i, c := range verifyMessageTestVec {
+		ok, err := bitcoin.VerifyMessage(c.address, c.sig, []byte(c.msg))
+		if nil != err {
+			t.Fatalf("#%d: %v", i, err)
+		}
+
+		if ok != c.isValid {
+			t.Fatalf("#%d: invalid verification: got %v, want %v", i, ok, c.isValid)
+		}
+	}
+}
+
+func TestVerifyMessageMalformed(t *testing.T) {
+	const (
+		address = "15CRxFdyRpGZLW9w8HnHvVduizdL5jKNbs"
+		sig     = "IPojfrX2dfPnH26UegfbGQQLrdK844DlHq5157/P6h57WyuS/Qsl+h/WSVGDF4MUi4rWSswW38oimDYfNNUBUOk="
+		msg     = "Trust no one"
+	)
+
+	testCases := []struct {
+		name         string
+		address, sig string
+	}{
+		{"bad address checksum", "15CRxFdyRpGZLW9w8HnHvVduizdL5jKNbt", sig},
+		{"bad address character", "15CRxFdyRpGZLW9w8HnHvVduizdL5jKNb0", sig},
+		{"non-P2PKH address", "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", sig},
+		{"bad base64", address, "not a base64 signature!"},
+	}
+
+	for _, c := range testCases {
+		if _, err := bitcoin.VerifyMessage(c.address, c.sig, []byte(msg)); nil == err {
+			t.Errorf("%s: verification should fail", c.name)
+		}
+	}
+
+	// a well-formed signature of the wrong length recovers nothing
+	if ok, err := bitcoin.VerifyMessage(address, "AAAA", []byte(msg)); (nil != err) || ok {
+		t.Fatalf("short signature should be invalid: got (%v,%v)", ok, err)
+	}
+}
+
+func TestSignMessageRandom(t *testing.T) {
+	for _, compressed := range []bool{true, false} {
+		priv, err := ecdsa.GenerateKey(elliptic.P256k1(), rand.Reader)
+		if nil != err {
+			t.Fatal(err)
+		}
+		address, err := bitcoin.P2PKHAddress(&priv.PublicKey, compressed, bitcoin.MainNetP2PKH)
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		// long enough to go with a multi-byte varint
+		msg := make([]byte, 300)
+		rand.Read(msg)
+
+		sig, err := bitcoin.SignMessage(priv, msg, compressed)
+		if nil != err {
+			t.Fatal(err)
+		}
+		if ok, err := bitcoin.VerifyMessage(address, sig, msg); (nil != err) || !ok {
+			t.Fatalf("compressed=%v: the signature should be valid: got (%v,%v)",
+				compressed, ok, err)
+		}
+
+		// the other form of the public key makes another address
+		other, _ := bitcoin.P2PKHAddress(&priv.PublicKey, !compressed, bitcoin.MainNetP2PKH)
+		if ok, _ := bitcoin.VerifyMessage(other, sig, msg); ok {
+			t.Fatalf("compressed=%v: the signature should be invalid for %s", compressed, other)
+		}
+	}
+}
+
+func TestBase58Check(t *testing.T) {
+	// the address of the public key hash of all zeros
+	const want = "1111111111111111111114oLvT2"
+
+	if got := bitcoin.Base58CheckEncode(bitcoin.MainNetP2PKH, make([]byte, 20)); got != want {
+		t.Fatalf("invalid encoding: got %s, want %s", got, want)
+	}
+
+	version, payload, err := bitcoin.Base58CheckDecode(want)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if (bitcoin.MainNetP2PKH != version) || (0 != new(big.Int).SetBytes(payload).Sign()) ||
+		(20 != len(payload)) {
+		t.Fatalf("invalid decoding: got (%x,%x)", version, payload)
+	}
+}
+
+func TestSignMessageRawKey(t *testing.T) {
+	// the message_sign test in src/test/util_tests.cpp of bitcoin core
+	const (
+		d   = "d97f5108f11cda6eeebaaa420fef0726b1f898060b98489fa3098463c0032866"
+		msg = "Trust no one"
+		sig = "IPojfrX2dfPnH26UegfbGQQLrdK844DlHq5157/P6h57WyuS/Qsl+h/WSVGDF4MUi4rWSswW38oimDYfNNUBUOk="
+	)
+
+	D, _ := new(big.Int).SetString(d, 16)
+	priv, err := ecdsa.NewPrivateKey(elliptic.P256k1(), D)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	got, err := bitcoin.SignMessage(priv, []byte(msg), true)
+	if nil != err {
+		t.Fatal(err)
+	} else if got != sig {
+		t.Fatalf("invalid signature: got %s, want %s", got, sig)
+	}
+}
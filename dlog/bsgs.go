@@ -0,0 +1,134 @@
+package dlog
+
+import (
+	"context"
+	"math/big"
+	"runtime"
+	"sync"
+
+	"github.com/sammy00/crypto/elliptic"
+)
+
+// Table is the precomputed table of baby steps j*G for j in [0,m), which can
+// be shared by concurrent solutions over the same curve
+type Table struct {
+	curve elliptic.Curve
+	m     uint64
+	steps map[string]uint64
+
+	// the giant step -m*G
+	giantX, giantY *big.Int
+}
+
+// NewTable precomputes m baby steps over the curve, which takes O(m) time
+// and space. A solution over an interval of length n then takes about n/m
+// giant steps, so m = sqrt(n) balances the cost.
+func NewTable(curve elliptic.Curve, m uint64) *Table {
+	if 0 == m {
+		m = 1
+	}
+
+	steps := make(map[string]uint64, m)
+
+	params := curve.Params()
+	x, y := new(big.Int), new(big.Int)
+	for j := uint64(0); j < m; j++ {
+		if _, ok := steps[pointKey(curve, x, y)]; ok {
+			// j*G wraps around for tiny groups
+			break
+		}
+		steps[pointKey(curve, x, y)] = j
+
+		x, y = curve.Add(x, y, params.Gx, params.Gy)
+	}
+
+	giantX, giantY := scalarBaseMult(curve, m)
+	giantX, giantY = neg(curve, giantX, giantY)
+
+	return &Table{curve: curve, m: m, steps: steps, giantX: giantX, giantY: giantY}
+}
+
+// Solve finds x in [lo,hi) such that x*G = (qx,qy) with the baby-step
+// giant-step algorithm. It returns ErrNotFound if there is no solution in the
+// interval, or the error of ctx if ctx is done before finishing.
+func (t *Table) Solve(ctx context.Context, qx, qy *big.Int, lo, hi uint64) (uint64, error) {
+	qx, qy, n, err := shift(t.curve, qx, qy, lo, hi)
+	if nil != err {
+		return 0, err
+	}
+
+	giants := n / t.m
+	if 0 != n%t.m {
+		giants++
+	}
+
+	workers := uint64(runtime.GOMAXPROCS(0))
+	if workers > giants {
+		workers = giants
+	}
+
+	// the stride -workers*m*G of every worker
+	strideX, strideY := t.curve.ScalarMult(t.giantX, t.giantY,
+		new(big.Int).SetUint64(workers).Bytes())
+
+	solveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg     sync.WaitGroup
+		once   sync.Once
+		result uint64
+		found  bool
+	)
+
+	// worker w checks Q-i*m*G against the table for i = w, w+workers, ...
+	x, y := qx, qy
+	for w := uint64(0); w < workers; w++ {
+		wg.Add(1)
+		go func(i uint64, x, y *big.Int) {
+			defer wg.Done()
+
+			for ; i < giants; i += workers {
+				select {
+				case <-solveCtx.Done():
+					return
+				default:
+				}
+
+				if j, ok := t.steps[pointKey(t.curve, x, y)]; ok {
+					if v := i*t.m + j; v < n {
+						once.Do(func() {
+							result, found = v, true
+							cancel()
+						})
+						return
+					}
+				}
+
+				x, y = t.curve.Add(x, y, strideX, strideY)
+			}
+		}(w, x, y)
+
+		x, y = t.curve.Add(x, y, t.giantX, t.giantY)
+	}
+	wg.Wait()
+
+	if found {
+		return lo + result, nil
+	}
+	if err := ctx.Err(); nil != err {
+		return 0, err
+	}
+
+	return 0, ErrNotFound
+}
+
+// BabyStepGiantStep finds x in [lo,hi) such that x*G = (qx,qy) with a table
+// of sqrt(hi-lo) baby steps built on the fly
+func BabyStepGiantStep(ctx context.Context, curve elliptic.Curve, qx, qy *big.Int, lo, hi uint64) (uint64, error) {
+	if hi <= lo {
+		return 0, ErrInvalidInterval
+	}
+
+	return NewTable(curve, sqrtCeil(hi-lo)).Solve(ctx, qx, qy, lo, hi)
+}
@@ -0,0 +1,76 @@
+// Package dlog solves the bounded discrete logarithm problem over elliptic
+// curves, that is, finding x in a known interval [lo,hi) such that x*G = Q,
+// where G is the base point of the curve.
+//
+// Two solvers are provided: the baby-step giant-step algorithm, which is
+// deterministic and whose precomputed table can be reused across many
+// solutions, and Pollard's kangaroo method, which needs only little memory.
+// Both take time in the order of sqrt(hi-lo) group operations, and spread
+// the work over runtime.GOMAXPROCS(0) goroutines.
+package dlog
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/sammy00/crypto/elliptic"
+)
+
+var (
+	// ErrNotFound is returned if no solution is found in the interval
+	ErrNotFound = errors.New("dlog: no solution in the interval")
+	// ErrInvalidInterval is returned if the interval is empty
+	ErrInvalidInterval = errors.New("dlog: invalid interval")
+)
+
+// scalarBaseMult calculates k*G
+func scalarBaseMult(curve elliptic.Curve, k uint64) (x, y *big.Int) {
+	return curve.ScalarBaseMult(new(big.Int).SetUint64(k).Bytes())
+}
+
+// neg calculates -(x,y)
+func neg(curve elliptic.Curve, x, y *big.Int) (xOut, yOut *big.Int) {
+	if 0 == y.Sign() {
+		return new(big.Int).Set(x), new(big.Int)
+	}
+
+	return new(big.Int).Set(x), new(big.Int).Sub(curve.Params().P, y)
+}
+
+// isInfinity checks if (x,y) is the point at infinity
+func isInfinity(x, y *big.Int) bool {
+	return (0 == x.Sign()) && (0 == y.Sign())
+}
+
+// pointKey encodes (x,y) into a key for maps, where the point at infinity is
+// keyed by the empty string
+func pointKey(curve elliptic.Curve, x, y *big.Int) string {
+	if isInfinity(x, y) {
+		return ""
+	}
+
+	return string(elliptic.MarshalCompressed(curve, x, y))
+}
+
+// shift maps Q into Q-lo*G, so as to reduce the interval [lo,hi) into [0,n)
+func shift(curve elliptic.Curve, x, y *big.Int, lo, hi uint64) (xOut, yOut *big.Int, n uint64, err error) {
+	if hi <= lo {
+		return nil, nil, 0, ErrInvalidInterval
+	}
+
+	xOut, yOut = scalarBaseMult(curve, lo)
+	xOut, yOut = neg(curve, xOut, yOut)
+	xOut, yOut = curve.Add(x, y, xOut, yOut)
+
+	return xOut, yOut, hi - lo, nil
+}
+
+// sqrtCeil returns ceil(sqrt(n))
+func sqrtCeil(n uint64) uint64 {
+	s := new(big.Int).Sqrt(new(big.Int).SetUint64(n)).Uint64()
+	if s*s < n {
+		s++
+	}
+
+	return s
+}
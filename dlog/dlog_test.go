@@ -0,0 +1,116 @@
+package dlog_test
+
+import (
+	"context"
+	stdElliptic "crypto/elliptic"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/sammy00/crypto/dlog"
+	"github.com/sammy00/crypto/elliptic"
+)
+
+type solver func(ctx context.Context, curve elliptic.Curve, qx, qy *big.Int,
+	lo, hi uint64) (uint64, error)
+
+var solvers = map[string]solver{
+	"BabyStepGiantStep": dlog.BabyStepGiantStep,
+	"Kangaroo":          dlog.Kangaroo,
+}
+
+var curves = map[string]elliptic.Curve{
+	"secp256k1": elliptic.P256k1(),
+	"P-256":     elliptic.FromStd(stdElliptic.P256()),
+}
+
+func scalarBaseMult(curve elliptic.Curve, k uint64) (x, y *big.Int) {
+	return curve.ScalarBaseMult(new(big.Int).SetUint64(k).Bytes())
+}
+
+func TestSolvers(t *testing.T) {
+	const lo, hi = 1 << 40, 1<<40 + 1<<20
+
+	for curveName, curve := range curves {
+		for solverName, solve := range solvers {
+			t.Run(curveName+"/"+solverName, func(t *testing.T) {
+				rng := rand.New(rand.NewSource(1))
+				expected := []uint64{lo, hi - 1, lo + uint64(rng.Int63n(hi-lo))}
+
+				for _, x := range expected {
+					qx, qy := scalarBaseMult(curve, x)
+
+					got, err := solve(context.Background(), curve, qx, qy, lo, hi)
+					if nil != err {
+						t.Fatalf("unexpected error for %d: %v", x, err)
+					}
+					if got != x {
+						t.Fatalf("invalid solution: got %d, want %d", got, x)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestSolversNotFound(t *testing.T) {
+	curve := elliptic.P256k1()
+	qx, qy := scalarBaseMult(curve, 1<<16)
+
+	for name, solve := range solvers {
+		if _, err := solve(context.Background(), curve, qx, qy, 0, 1<<12); dlog.ErrNotFound != err {
+			t.Fatalf("%s: invalid error: got %v, want %v", name, err, dlog.ErrNotFound)
+		}
+
+		if _, err := solve(context.Background(), curve, qx, qy, 1, 1); dlog.ErrInvalidInterval != err {
+			t.Fatalf("%s: invalid error: got %v, want %v", name, err, dlog.ErrInvalidInterval)
+		}
+	}
+}
+
+func TestSolversCanceled(t *testing.T) {
+	curve := elliptic.P256k1()
+	qx, qy := scalarBaseMult(curve, 1<<16)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for name, solve := range solvers {
+		if _, err := solve(ctx, curve, qx, qy, 0, 1<<20); context.Canceled != err {
+			t.Fatalf("%s: invalid error: got %v, want %v", name, err, context.Canceled)
+		}
+	}
+}
+
+func TestKangarooWideInterval(t *testing.T) {
+	curve := elliptic.P256k1()
+	qx, qy := scalarBaseMult(curve, 1<<62+12345)
+
+	// the walk over [0,2^64-1) cannot finish in time, but must not panic
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	_, err := dlog.Kangaroo(ctx, curve, qx, qy, 0, math.MaxUint64)
+	if context.DeadlineExceeded != err {
+		t.Fatalf("invalid error: got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestTableReuse(t *testing.T) {
+	curve := elliptic.P256k1()
+	table := dlog.NewTable(curve, 1<<8)
+
+	for _, x := range []uint64{0, 1, 255, 256, 1000, 1<<16 - 1} {
+		qx, qy := scalarBaseMult(curve, x)
+
+		got, err := table.Solve(context.Background(), qx, qy, 0, 1<<16)
+		if nil != err {
+			t.Fatalf("unexpected error for %d: %v", x, err)
+		}
+		if got != x {
+			t.Fatalf("invalid solution: got %d, want %d", got, x)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package dlog
+
+import (
+	"context"
+	"math/big"
+	"math/bits"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sammy00/crypto/elliptic"
+)
+
+// trap records the distance travelled by a kangaroo upon reaching a
+// distinguished point
+type trap struct {
+	distance uint64
+	tame     bool
+}
+
+// kangaroo is either a tame one starting from a known multiple of G, or a
+// wild one starting from Q plus a known multiple of G
+type kangaroo struct {
+	x, y     *big.Int
+	distance uint64
+	tame     bool
+}
+
+// Kangaroo finds x in [lo,hi) such that x*G = (qx,qy) with the parallel
+// version of Pollard's kangaroo method using distinguished points, as is
+// described by van Oorschot and Wiener. Being a probabilistic method, it
+// returns ErrNotFound after walking some dozens of times the expected number
+// of steps, which almost surely means there is no solution in the interval.
+// The error of ctx is returned if ctx is done before finishing.
+func Kangaroo(ctx context.Context, curve elliptic.Curve, qx, qy *big.Int, lo, hi uint64) (uint64, error) {
+	qx, qy, n, err := shift(curve, qx, qy, lo, hi)
+	if nil != err {
+		return 0, err
+	}
+	if isInfinity(qx, qy) {
+		return lo, nil
+	}
+
+	// half of the kangaroos are tame, and the other half are wild
+	herd := 2 * runtime.GOMAXPROCS(0)
+	sqrtN := sqrtCeil(n)
+
+	// jumps are powers of 2 with the mean about herd*sqrt(n)/4
+	mean := uint64(herd) * sqrtN / 4
+	if 0 == mean {
+		mean = 1
+	}
+	nJumps := bits.Len64(mean) + 1
+	jumps := make([][2]*big.Int, nJumps)
+	for i := range jumps {
+		jumps[i][0], jumps[i][1] = scalarBaseMult(curve, 1<<uint(i))
+	}
+
+	// about 1/2^dpBits of the points are distinguished, so that the trap map
+	// holds about herd*2^4 points for an expected walk
+	dpBits := bits.Len64(sqrtN) - bits.Len(uint(herd)) - 4
+	if dpBits < 0 {
+		dpBits = 0
+	}
+	dpMask := uint64(1)<<uint(dpBits) - 1
+
+	solveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu    sync.Mutex
+		traps = make(map[string]trap)
+
+		wg     sync.WaitGroup
+		once   sync.Once
+		result uint64
+		found  bool
+
+		// the steps left for the whole herd
+		budget = int64(64 * (sqrtN + uint64(herd)<<uint(dpBits)))
+	)
+
+	// restart puts the kangaroo to a new random starting point, where the
+	// tame one starts from [n/2,n] so its distance never exceeds n
+	restart := func(k *kangaroo, rng *rand.Rand) {
+		k.distance = rng.Uint64() % (n/2 + 1)
+		if k.tame {
+			k.distance += n / 2
+			k.x, k.y = scalarBaseMult(curve, k.distance)
+		} else {
+			k.x, k.y = scalarBaseMult(curve, k.distance)
+			k.x, k.y = curve.Add(k.x, k.y, qx, qy)
+		}
+	}
+
+	// trapped checks if the kangaroo lands on the trap of another, and
+	// returns whether the kangaroo should keep walking
+	trapped := func(k *kangaroo, rng *rand.Rand) bool {
+		key := pointKey(curve, k.x, k.y)
+
+		mu.Lock()
+		t, ok := traps[key]
+		if !ok {
+			traps[key] = trap{k.distance, k.tame}
+		}
+		mu.Unlock()
+
+		switch {
+		case !ok:
+			return true
+		case t.tame == k.tame:
+			// both follow the same path from now on, so one must restart
+			restart(k, rng)
+			return true
+		}
+
+		// tame distance = x + wild distance
+		tame, wild := t.distance, k.distance
+		if k.tame {
+			tame, wild = wild, tame
+		}
+		if (tame < wild) || (tame-wild >= n) {
+			return true
+		}
+
+		once.Do(func() {
+			result, found = tame-wild, true
+			cancel()
+		})
+		return false
+	}
+
+	for i := 0; i < herd; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			rng := rand.New(rand.NewSource(int64(i)))
+			k := &kangaroo{tame: 0 == i%2}
+			restart(k, rng)
+
+			for steps := 0; ; steps++ {
+				if 0 == steps%64 {
+					select {
+					case <-solveCtx.Done():
+						return
+					default:
+					}
+
+					if atomic.AddInt64(&budget, -64) < 0 {
+						return
+					}
+				}
+
+				low := uint64(0)
+				if words := k.x.Bits(); len(words) > 0 {
+					low = uint64(words[0])
+				}
+
+				if 0 == low&dpMask && !trapped(k, rng) {
+					return
+				}
+
+				j := int((low >> uint(dpBits)) % uint64(nJumps))
+				distance, carry := bits.Add64(k.distance, 1<<uint(j), 0)
+				if 0 != carry {
+					// the distance no longer fits, which happens only for
+					// intervals close to 2^64 after a fruitless walk
+					restart(k, rng)
+					continue
+				}
+				k.x, k.y = curve.Add(k.x, k.y, jumps[j][0], jumps[j][1])
+				k.distance = distance
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if found {
+		return lo + result, nil
+	}
+	if err := ctx.Err(); nil != err {
+		return 0, err
+	}
+
+	return 0, ErrNotFound
+}
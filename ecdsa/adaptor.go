@@ -0,0 +1,334 @@
+package ecdsa
+
+// References:
+//   [ZKP]: the ecdsa_adaptor module of libsecp256k1-zkp,
+//     https://github.com/BlockstreamResearch/secp256k1-zkp
+//   [DLC]: the DLEQ proof of the DLC specification,
+//     https://github.com/discreetlogcontracts/dlcspecs
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/sammy00/crypto/elliptic"
+)
+
+// ErrInvalidAdaptor is returned if an adaptor signature is malformed or
+// mismatches the signature or the encryption key
+var ErrInvalidAdaptor = errors.New("ecdsa: invalid adaptor signature")
+
+// AdaptorSignature is an ECDSA signature encrypted under the encryption key
+// Y = y*G as [ZKP] specifies, which is made of R = k*Y, Ra = k*G,
+// SHat = k^{-1}*(e+r*x) where r = x(R) mod N, and the DLEQ proof (E,S) of
+// log_G(Ra) = log_Y(R). Anyone can check it is the encryption of a valid
+// signature, which can only be decrypted by the holder of y, who then leaks
+// y to the signer by publishing the decrypted signature.
+type AdaptorSignature struct {
+	Curve    elliptic.Curve
+	Rx, Ry   *big.Int
+	RaX, RaY *big.Int
+	SHat     *big.Int
+	E, S     *big.Int
+}
+
+// AdaptorSignatureLen is the length in bytes of an adaptor signature over
+// secp256k1, i.e., R (33) || Ra (33) || SHat (32) || E (32) || S (32)
+const AdaptorSignatureLen = 162
+
+// EncSign signs the hash with priv and encrypts the signature under the
+// encryption key Y. The nonces are derived with the HMAC-DRBG of RFC 6979
+// over SHA-256 hedged with fresh randomness, so that a broken rand never
+// leaks the private key.
+func EncSign(rand io.Reader, priv *PrivateKey, Y *PublicKey,
+	hash []byte) (*AdaptorSignature, error) {
+	c := priv.Curve
+	N := c.Params().N
+
+	if (nil == priv.D) || (0 == priv.D.Sign()) {
+		return nil, errDestroyedKey
+	}
+	if c != Y.Curve {
+		return nil, errors.New("ecdsa: the encryption key is over another curve")
+	} else if err := Y.Validate(); nil != err {
+		return nil, err
+	}
+
+	aux := make([]byte, 32)
+	if _, err := io.ReadFull(rand, aux); nil != err {
+		return nil, err
+	}
+	Y33 := elliptic.MarshalCompressed(c, Y.X, Y.Y)
+
+	// the encryption key and aux go as the additional data of the DRBG
+	extra := sha256.Sum256(append(append([]byte("ECDSAadaptor/non"), Y33...), aux...))
+	g := NewNonceRFC6979(c, priv.D, hash, crypto.SHA256.New, extra[:])
+	defer g.Wipe()
+
+	var secrets secretBuffer
+	defer secrets.wipe()
+
+	e := hashToInt(hash, c)
+	for {
+		k, err := g.Next()
+		if nil != err {
+			return nil, err
+		}
+		secrets.track(k)
+		kBytes := secrets.trackBytes(k.Bytes())
+
+		a := &AdaptorSignature{Curve: c}
+		a.RaX, a.RaY = c.ScalarBaseMult(kBytes)
+		a.Rx, a.Ry = c.ScalarMult(Y.X, Y.Y, kBytes)
+
+		r := new(big.Int).Mod(a.Rx, N)
+		if 0 == r.Sign() {
+			continue
+		}
+
+		// SHat = k^{-1}*(e+r*x)
+		t := secrets.track(new(big.Int).Mul(priv.D, r))
+		t.Add(t, e)
+		t.Mul(t, secrets.track(fermatInverse(k, N)))
+		a.SHat = new(big.Int).Mod(t, N)
+		if 0 == a.SHat.Sign() {
+			continue
+		}
+
+		a.E, a.S, err = dleqProve(c, k, Y.X, Y.Y, a.RaX, a.RaY, a.Rx, a.Ry, aux)
+		if nil != err {
+			return nil, err
+		}
+
+		return a, nil
+	}
+}
+
+// EncVerify checks the adaptor signature is the encryption under Y of a
+// valid signature of the hash by pub, i.e., the DLEQ proof holds and
+// (e/SHat)*G + (r/SHat)*X = Ra. Both pub and Y must be valid public keys.
+func EncVerify(pub, Y *PublicKey, hash []byte, a *AdaptorSignature) bool {
+	if (nil == pub) || (nil == Y) || (nil == a) {
+		return false
+	}
+	if (nil != pub.Validate()) || (nil != Y.Validate()) {
+		return false
+	}
+
+	c := pub.Curve
+	N := c.Params().N
+
+	if (c != Y.Curve) || (c != a.Curve) || !a.isWellFormed() {
+		return false
+	}
+
+	if !dleqVerify(c, Y.X, Y.Y, a.RaX, a.RaY, a.Rx, a.Ry, a.E, a.S) {
+		return false
+	}
+
+	r := new(big.Int).Mod(a.Rx, N)
+	if 0 == r.Sign() {
+		return false
+	}
+
+	w := new(big.Int).ModInverse(a.SHat, N)
+	u1 := hashToInt(hash, c)
+	u1.Mul(u1, w).Mod(u1, N)
+	u2 := w.Mul(r, w).Mod(w, N)
+
+	x1, y1 := c.ScalarBaseMult(u1.Bytes())
+	x2, y2 := c.ScalarMult(pub.X, pub.Y, u2.Bytes())
+	x, y := c.Add(x1, y1, x2, y2)
+
+	return (0 == x.Cmp(a.RaX)) && (0 == y.Cmp(a.RaY))
+}
+
+// Decrypt decrypts the adaptor signature into the signature (r,s) with the
+// decryption key y, where s = SHat/y is normalized to the low one for
+// secp256k1
+func (a *AdaptorSignature) Decrypt(y *big.Int) (r, s *big.Int, err error) {
+	N := a.Curve.Params().N
+	if (y.Sign() <= 0) || (y.Cmp(N) >= 0) {
+		return nil, nil, errors.New("ecdsa: the decryption key is out of range")
+	}
+	if !a.isWellFormed() {
+		return nil, nil, ErrInvalidAdaptor
+	}
+
+	s = new(big.Int).Mul(a.SHat, fermatInverse(y, N))
+	s.Mod(s, N)
+	if prefersLowS(a.Curve) {
+		s = NormalizeS(a.Curve, s)
+	}
+
+	return new(big.Int).Mod(a.Rx, N), s, nil
+}
+
+// Recover extracts the decryption key y of Y from the signature (r,s)
+// decrypted from the adaptor signature, as y = ±SHat/s
+func (a *AdaptorSignature) Recover(Y *PublicKey, r, s *big.Int) (*big.Int, error) {
+	c := a.Curve
+	N := c.Params().N
+
+	if (c != Y.Curve) || !a.isWellFormed() {
+		return nil, ErrInvalidAdaptor
+	}
+	if (s.Sign() <= 0) || (s.Cmp(N) >= 0) || (0 != r.Cmp(new(big.Int).Mod(a.Rx, N))) {
+		return nil, ErrInvalidAdaptor
+	}
+
+	y := new(big.Int).Mul(a.SHat, fermatInverse(s, N))
+	y.Mod(y, N)
+
+	// s may have been negated into the low one
+	for i := 0; i < 2; i++ {
+		x, yy := c.ScalarBaseMult(y.Bytes())
+		if (0 == x.Cmp(Y.X)) && (0 == yy.Cmp(Y.Y)) {
+			return y, nil
+		}
+		y.Sub(N, y)
+	}
+
+	return nil, ErrInvalidAdaptor
+}
+
+// Bytes serializes the adaptor signature as R || Ra || SHat || E || S,
+// where the points go in the compressed form and the scalars as long as N
+func (a *AdaptorSignature) Bytes() []byte {
+	out := elliptic.MarshalCompressed(a.Curve, a.Rx, a.Ry)
+	out = append(out, elliptic.MarshalCompressed(a.Curve, a.RaX, a.RaY)...)
+	out = append(out, int2octets(a.SHat, a.Curve)...)
+	out = append(out, int2octets(a.E, a.Curve)...)
+
+	return append(out, int2octets(a.S, a.Curve)...)
+}
+
+// ParseAdaptorSignature decodes the adaptor signature output by Bytes
+func ParseAdaptorSignature(c elliptic.Curve, data []byte) (*AdaptorSignature, error) {
+	pointLen := 1 + (c.Params().BitSize+7)/8
+	scalarLen := (c.Params().N.BitLen() + 7) / 8
+	if len(data) != 2*pointLen+3*scalarLen {
+		return nil, ErrInvalidAdaptor
+	}
+
+	a := &AdaptorSignature{Curve: c}
+	a.Rx, a.Ry = elliptic.UnmarshalCompressed(c, data[:pointLen])
+	a.RaX, a.RaY = elliptic.UnmarshalCompressed(c, data[pointLen:2*pointLen])
+	if (nil == a.Rx) || (nil == a.RaX) {
+		return nil, ErrInvalidAdaptor
+	}
+
+	data = data[2*pointLen:]
+	a.SHat = new(big.Int).SetBytes(data[:scalarLen])
+	a.E = new(big.Int).SetBytes(data[scalarLen : 2*scalarLen])
+	a.S = new(big.Int).SetBytes(data[2*scalarLen:])
+	if !a.isWellFormed() {
+		return nil, ErrInvalidAdaptor
+	}
+
+	return a, nil
+}
+
+// isWellFormed checks R and Ra are valid points, SHat is in [1,N-1], and E
+// and S are in [0,N-1]
+func (a *AdaptorSignature) isWellFormed() bool {
+	if (nil == a.Curve) || (nil == a.SHat) || (nil == a.E) || (nil == a.S) {
+		return false
+	}
+
+	N := a.Curve.Params().N
+	if (a.SHat.Sign() <= 0) || (a.SHat.Cmp(N) >= 0) ||
+		(a.E.Sign() < 0) || (a.E.Cmp(N) >= 0) || (a.S.Sign() < 0) || (a.S.Cmp(N) >= 0) {
+		return false
+	}
+
+	R := &PublicKey{Curve: a.Curve, X: a.Rx, Y: a.Ry}
+	Ra := &PublicKey{Curve: a.Curve, X: a.RaX, Y: a.RaY}
+
+	return (nil == R.Validate()) && (nil == Ra.Validate())
+}
+
+// dleqProve proves log_G(P1) = log_Gen2(P2) = x in zero knowledge as [DLC]
+// specifies, i.e., with the nonce k, A1 = k*G and A2 = k*Gen2, it outputs
+// e = H(P1 || Gen2 || P2 || A1 || A2) and s = k + e*x.
+func dleqProve(c elliptic.Curve, x *big.Int, gen2X, gen2Y, p1X, p1Y, p2X, p2Y *big.Int,
+	aux []byte) (e, s *big.Int, err error) {
+	N := c.Params().N
+
+	P1 := elliptic.MarshalCompressed(c, p1X, p1Y)
+	P2 := elliptic.MarshalCompressed(c, p2X, p2Y)
+	Gen2 := elliptic.MarshalCompressed(c, gen2X, gen2Y)
+
+	// the nonce is bound to the statement by the digest and the extra data
+	digest := sha256.Sum256(append(append([]byte{}, P1...), P2...))
+	extra := sha256.Sum256(append(append([]byte("DLEQ/non"), Gen2...), aux...))
+	g := NewNonceRFC6979(c, x, digest[:], crypto.SHA256.New, extra[:])
+	defer g.Wipe()
+
+	var secrets secretBuffer
+	defer secrets.wipe()
+
+	k, err := g.Next()
+	if nil != err {
+		return nil, nil, err
+	}
+	secrets.track(k)
+	kBytes := secrets.trackBytes(k.Bytes())
+
+	a1X, a1Y := c.ScalarBaseMult(kBytes)
+	a2X, a2Y := c.ScalarMult(gen2X, gen2Y, kBytes)
+
+	e = dleqChallenge(c, Gen2, P1, P2, a1X, a1Y, a2X, a2Y)
+
+	// s = k + e*x
+	s = secrets.track(new(big.Int).Mul(e, x))
+	s = new(big.Int).Add(s, k)
+	s.Mod(s, N)
+
+	return e, s, nil
+}
+
+// dleqVerify checks the proof (e,s) of log_G(P1) = log_Gen2(P2) by
+// recomputing A1 = s*G - e*P1 and A2 = s*Gen2 - e*P2
+func dleqVerify(c elliptic.Curve, gen2X, gen2Y, p1X, p1Y, p2X, p2Y, e, s *big.Int) bool {
+	N := c.Params().N
+	negE := new(big.Int).Sub(N, e).Bytes()
+
+	x1, y1 := c.ScalarBaseMult(s.Bytes())
+	x2, y2 := c.ScalarMult(p1X, p1Y, negE)
+	a1X, a1Y := c.Add(x1, y1, x2, y2)
+
+	x1, y1 = c.ScalarMult(gen2X, gen2Y, s.Bytes())
+	x2, y2 = c.ScalarMult(p2X, p2Y, negE)
+	a2X, a2Y := c.Add(x1, y1, x2, y2)
+
+	if ((0 == a1X.Sign()) && (0 == a1Y.Sign())) || ((0 == a2X.Sign()) && (0 == a2Y.Sign())) {
+		return false
+	}
+
+	Gen2 := elliptic.MarshalCompressed(c, gen2X, gen2Y)
+	P1 := elliptic.MarshalCompressed(c, p1X, p1Y)
+	P2 := elliptic.MarshalCompressed(c, p2X, p2Y)
+
+	return 0 == e.Cmp(dleqChallenge(c, Gen2, P1, P2, a1X, a1Y, a2X, a2Y))
+}
+
+// dleqChallenge computes the tagged hash "DLEQ" of P1 || Gen2 || P2 || A1
+// || A2 as an integer modulo N
+func dleqChallenge(c elliptic.Curve, Gen2, P1, P2 []byte, a1X, a1Y, a2X, a2Y *big.Int) *big.Int {
+	tag := sha256.Sum256([]byte("DLEQ"))
+
+	h := sha256.New()
+	h.Write(tag[:])
+	h.Write(tag[:])
+	h.Write(P1)
+	h.Write(Gen2)
+	h.Write(P2)
+	h.Write(elliptic.MarshalCompressed(c, a1X, a1Y))
+	h.Write(elliptic.MarshalCompressed(c, a2X, a2Y))
+
+	e := new(big.Int).SetBytes(h.Sum(nil))
+	return e.Mod(e, c.Params().N)
+}
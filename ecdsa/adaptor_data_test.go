@@ -0,0 +1,60 @@
+package ecdsa_test
+
+// The vectors below follow the ecdsa_adaptor module of libsecp256k1-zkp,
+// i.e., R || R' || s' || e || s with the DLEQ challenge as the tagged hash
+// "DLEQ" of R' || Y || R || Q1 || Q2.
+//
+// The first one is the verification vector of the ECDSA adaptor signature
+// test vectors published in dlcspecs, with the decrypted signature. The
+// others are computed by an independent Python implementation of the
+// specification with fixed nonces, where the signing key is adaptorX and the
+// message hash is SHA256("adaptor signature test vector").
+
+const (
+	adaptorX    = "02e2e0e912f4fab3c0f7757237982b1977f506205bfdf4b7c8852ca23d9fbdbab8"
+	adaptorHash = "2acbda0a7a7aadd59b8e89b2d7d0df0fe2c53fa5e675b3964bb44d58dbf8409c"
+)
+
+type adaptorTest struct {
+	X, hash string // the signing key and the message hash
+	y, Y    string // the decryption key pair
+	adaptor string
+	r, s    string // the decrypted signature
+}
+
+var adaptorTestVec = []adaptorTest{
+	{ // dlcspecs
+		"035be5e9478209674a96e60f1f037f6176540fd001fa1d64694770c56a7709c42c",
+		"8131e6f4b45754f2c90bd06688ceeabc0c45055460729928b4eecf11026a9e2d",
+		"0b2aba63b885a0f0e96fa0f303920c7fb7431ddfa94376ad94d969fbf4109dc8",
+		"02c2662c97488b07b6e819124b8989849206334a4c2fbdf691f7b34d2b16e9c293",
+		"03424d14a5471c048ab87b3b83f6085d125d5864249ae4297a57c84e74710bb6730223f325042fce53" +
+			"5d040fee52ec13231bf709ccd84233c6944b90317e62528b2527dff9d659a96db4c99f9750168308" +
+			"633c1867b70f3a18fb0f4539a1aecedcd1fc0148fc22f36b6303083ece3f872b18e35d368b3958ef" +
+			"e5fb081f7716736ccb598d269aa3084d57e1855e1ea9a45efc10463bbf32ae378029f5763ceb40173f",
+		"424d14a5471c048ab87b3b83f6085d125d5864249ae4297a57c84e74710bb673",
+		"29e80e0ee60e57af3e625bbae1672b1ecaa58effe613426b024fa1621d903394",
+	},
+	{ // s'/y is low already
+		adaptorX, adaptorHash,
+		"07dc6f4190fa522b8ebba1590fa6b81c0154c33916bffea03431b893d416e5ba",
+		"026dbf0189c0876f2fb190c310070cfed2585e54dc4002d647e96d94b5991133a1",
+		"02976d4163820fbb0cdeae0e36bb720bdaccbd4fb9cbeee02ee77fe96c08347b8702a7a8d6450e317a" +
+			"0649ea12e771c9362b46c542e66390ad43bb2e8fda8a8accbcb025badddf0789d81d90d55f2a80e011" +
+			"0a319d7bb2c2b30cdf5e4d7c8f6e9ecd49dc0adab1ae41673619bffe610460803eae66f1bbc4adb745" +
+			"5195fdd8edb6b55384ce0e9d7844d64bd415c26f12ee1894900b595b61c05edffe7c931a56a0d8",
+		"976d4163820fbb0cdeae0e36bb720bdaccbd4fb9cbeee02ee77fe96c08347b87",
+		"3421450f636d5ee839df46c375bce8194181f0b9389e9f7f80a89acabe81fd25",
+	},
+	{ // s'/y is high, and negated by Decrypt
+		adaptorX, adaptorHash,
+		"1583e92d12a0dabaee3e7696242c90f144fb8bf9e526de178092e5d025f03435",
+		"03b0c85a6fbcb31014d31977717d6e8c268d7369906a1cbe468ae2d08969cec32f",
+		"0391f46dabaecae219ad52bbbd646603543fe4cdaccc61e2936470c7cfe7dc1b0b03d5a61b04074d31" +
+			"614c8743753de3f3b4bb74fb325c01e187a71b9141fd850463af7a8c22306a1dae4c2b938e1da0c121" +
+			"3906b456a1cc7e1f7e6090a7922f2f241a9d53f30e03a2832d6424b3e3148458628519d26566b3da79" +
+			"f0867147d2d10cc287baae12a325572c453b05f643ee23e701842c6fb3c139aeb9bf35ff9887a5",
+		"91f46dabaecae219ad52bbbd646603543fe4cdaccc61e2936470c7cfe7dc1b0b",
+		"078d5c2f39703dc588fd1dea72bbfa4c858692fafa85a6ddf69120c0355e1b0f",
+	},
+}
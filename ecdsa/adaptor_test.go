@@ -0,0 +1,275 @@
+package ecdsa_test
+
+import (
+	stdElliptic "crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+)
+
+func TestAdaptorSignature(t *testing.T) {
+	curves := map[string]elliptic.Curve{
+		"secp256k1": elliptic.P256k1(),
+		"P-256":     elliptic.FromStd(stdElliptic.P256()),
+	}
+
+	for name, curve := range curves {
+		t.Run(name, func(t *testing.T) {
+			priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+			if nil != err {
+				t.Fatal(err)
+			}
+			// the encryption key pair
+			dec, err := ecdsa.GenerateKey(curve, rand.Reader)
+			if nil != err {
+				t.Fatal(err)
+			}
+			digest := sha256.Sum256([]byte("atomic swap"))
+
+			a, err := ecdsa.EncSign(rand.Reader, priv, &dec.PublicKey, digest[:])
+			if nil != err {
+				t.Fatal(err)
+			}
+			if !ecdsa.EncVerify(&priv.PublicKey, &dec.PublicKey, digest[:], a) {
+				t.Fatal("the adaptor signature should be valid")
+			}
+
+			// the adaptor signature itself is no valid signature
+			if ecdsa.Verify(&priv.PublicKey, digest[:], new(big.Int).Mod(a.Rx, curve.Params().N), a.SHat) {
+				t.Fatal("the adaptor signature should not verify as a signature")
+			}
+
+			r, s, err := a.Decrypt(dec.D)
+			if nil != err {
+				t.Fatal(err)
+			}
+			if !ecdsa.Verify(&priv.PublicKey, digest[:], r, s) {
+				t.Fatal("the decrypted signature should be valid")
+			}
+
+			y, err := a.Recover(&dec.PublicKey, r, s)
+			if nil != err {
+				t.Fatal(err)
+			} else if 0 != y.Cmp(dec.D) {
+				t.Fatalf("invalid decryption key: got %x, want %x", y, dec.D)
+			}
+
+			// the negated s recovers y as well
+			negS := new(big.Int).Sub(curve.Params().N, s)
+			if y, err := a.Recover(&dec.PublicKey, r, negS); (nil != err) || (0 != y.Cmp(dec.D)) {
+				t.Fatalf("the negated s should recover y: got (%x,%v)", y, err)
+			}
+		})
+	}
+}
+
+func TestAdaptorSignatureVectors(t *testing.T) {
+	curve := elliptic.P256k1()
+
+	for i, c := range adaptorTestVec {
+		pub := mustCompressedKey(t, curve, c.X)
+		hash, _ := hex.DecodeString(c.hash)
+		Y := mustCompressedKey(t, curve, c.Y)
+		data, _ := hex.DecodeString(c.adaptor)
+
+		a, err := ecdsa.ParseAdaptorSignature(curve, data)
+		if nil != err {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if !ecdsa.EncVerify(pub, Y, hash, a) {
+			t.Fatalf("#%d: the adaptor signature should be valid", i)
+		}
+		if ecdsa.EncVerify(pub, Y, hash[1:], a) {
+			t.Fatalf("#%d: the adaptor signature should be invalid for another hash", i)
+		}
+
+		y, _ := new(big.Int).SetString(c.y, 16)
+		r, s, err := a.Decrypt(y)
+		if nil != err {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if got := fmt.Sprintf("%064x", r); got != c.r {
+			t.Fatalf("#%d: invalid r: got %s, want %s", i, got, c.r)
+		}
+		if got := fmt.Sprintf("%064x", s); got != c.s {
+			t.Fatalf("#%d: invalid s: got %s, want %s", i, got, c.s)
+		}
+		if !ecdsa.Verify(pub, hash, r, s) {
+			t.Fatalf("#%d: the decrypted signature should be valid", i)
+		}
+
+		if got, err := a.Recover(Y, r, s); nil != err {
+			t.Fatalf("#%d: %v", i, err)
+		} else if 0 != got.Cmp(y) {
+			t.Fatalf("#%d: invalid decryption key: got %x, want %s", i, got, c.y)
+		}
+	}
+}
+
+// mustCompressedKey decodes the public key in the compressed form
+func mustCompressedKey(t *testing.T, curve elliptic.Curve, data string) *ecdsa.PublicKey {
+	b, _ := hex.DecodeString(data)
+
+	x, y := elliptic.UnmarshalCompressed(curve, b)
+	if nil == x {
+		t.Fatalf("invalid public key %s", data)
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+}
+
+func TestAdaptorSignatureLowS(t *testing.T) {
+	curve := elliptic.P256k1()
+
+	for i := 0; i < 8; i++ {
+		priv, _ := ecdsa.GenerateKey(curve, rand.Reader)
+		dec, _ := ecdsa.GenerateKey(curve, rand.Reader)
+		digest := sha256.Sum256([]byte{byte(i)})
+
+		a, err := ecdsa.EncSign(rand.Reader, priv, &dec.PublicKey, digest[:])
+		if nil != err {
+			t.Fatal(err)
+		}
+		_, s, err := a.Decrypt(dec.D)
+		if nil != err {
+			t.Fatal(err)
+		}
+		if !ecdsa.IsLowS(curve, s) {
+			t.Fatal("the decrypted s should be low for secp256k1")
+		}
+	}
+}
+
+func TestAdaptorSignatureInvalid(t *testing.T) {
+	curve := elliptic.P256k1()
+	N := curve.Params().N
+
+	priv, _ := ecdsa.GenerateKey(curve, rand.Reader)
+	dec, _ := ecdsa.GenerateKey(curve, rand.Reader)
+	other, _ := ecdsa.GenerateKey(curve, rand.Reader)
+	digest := sha256.Sum256([]byte("atomic swap"))
+
+	a, err := ecdsa.EncSign(rand.Reader, priv, &dec.PublicKey, digest[:])
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	tamper := func(f func(a *ecdsa.AdaptorSignature)) *ecdsa.AdaptorSignature {
+		b := *a
+		f(&b)
+		return &b
+	}
+	add1 := func(x *big.Int) *big.Int {
+		y := new(big.Int).Add(x, big.NewInt(1))
+		return y.Mod(y, N)
+	}
+
+	testCases := []struct {
+		name    string
+		pub, Y  *ecdsa.PublicKey
+		hash    []byte
+		adaptor *ecdsa.AdaptorSignature
+	}{
+		{"wrong signer", &other.PublicKey, &dec.PublicKey, digest[:], a},
+		{"wrong encryption key", &priv.PublicKey, &other.PublicKey, digest[:], a},
+		{"wrong hash", &priv.PublicKey, &dec.PublicKey, []byte("another hash"), a},
+		{"tampered SHat", &priv.PublicKey, &dec.PublicKey, digest[:],
+			tamper(func(a *ecdsa.AdaptorSignature) { a.SHat = add1(a.SHat) })},
+		{"tampered E", &priv.PublicKey, &dec.PublicKey, digest[:],
+			tamper(func(a *ecdsa.AdaptorSignature) { a.E = add1(a.E) })},
+		{"tampered S", &priv.PublicKey, &dec.PublicKey, digest[:],
+			tamper(func(a *ecdsa.AdaptorSignature) { a.S = add1(a.S) })},
+		{"swapped R and Ra", &priv.PublicKey, &dec.PublicKey, digest[:],
+			tamper(func(a *ecdsa.AdaptorSignature) {
+				a.Rx, a.Ry, a.RaX, a.RaY = a.RaX, a.RaY, a.Rx, a.Ry
+			})},
+		{"R off curve", &priv.PublicKey, &dec.PublicKey, digest[:],
+			tamper(func(a *ecdsa.AdaptorSignature) { a.Ry = add1(a.Ry) })},
+		{"nil signer", nil, &dec.PublicKey, digest[:], a},
+		{"nil encryption key", &priv.PublicKey, nil, digest[:], a},
+		{"nil adaptor", &priv.PublicKey, &dec.PublicKey, digest[:], nil},
+		{"incomplete signer", &ecdsa.PublicKey{Curve: curve}, &dec.PublicKey, digest[:], a},
+		{"incomplete encryption key", &priv.PublicKey, &ecdsa.PublicKey{Curve: curve},
+			digest[:], a},
+		{"signer off curve", &ecdsa.PublicKey{Curve: curve, X: priv.X, Y: add1(priv.Y)},
+			&dec.PublicKey, digest[:], a},
+		{"encryption key off curve", &priv.PublicKey,
+			&ecdsa.PublicKey{Curve: curve, X: dec.X, Y: add1(dec.Y)}, digest[:], a},
+	}
+
+	for _, c := range testCases {
+		if ecdsa.EncVerify(c.pub, c.Y, c.hash, c.adaptor) {
+			t.Errorf("%s: the adaptor signature should be invalid", c.name)
+		}
+	}
+
+	// a signature unrelated to the adaptor recovers nothing
+	r, s, err := ecdsa.Sign(rand.Reader, priv, digest[:])
+	if nil != err {
+		t.Fatal(err)
+	}
+	if _, err := a.Recover(&dec.PublicKey, r, s); nil == err {
+		t.Fatal("the unrelated signature should recover nothing")
+	}
+
+	// decrypting with the wrong key gives an invalid signature
+	r, s, err = a.Decrypt(other.D)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if ecdsa.Verify(&priv.PublicKey, digest[:], r, s) {
+		t.Fatal("the signature decrypted by the wrong key should be invalid")
+	}
+}
+
+func TestAdaptorSignatureSerialization(t *testing.T) {
+	curve := elliptic.P256k1()
+
+	priv, _ := ecdsa.GenerateKey(curve, rand.Reader)
+	dec, _ := ecdsa.GenerateKey(curve, rand.Reader)
+	digest := sha256.Sum256([]byte("atomic swap"))
+
+	a, err := ecdsa.EncSign(rand.Reader, priv, &dec.PublicKey, digest[:])
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	data := a.Bytes()
+	if ecdsa.AdaptorSignatureLen != len(data) {
+		t.Fatalf("invalid length: got %d, want %d", len(data), ecdsa.AdaptorSignatureLen)
+	}
+
+	parsed, err := ecdsa.ParseAdaptorSignature(curve, data)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if !ecdsa.EncVerify(&priv.PublicKey, &dec.PublicKey, digest[:], parsed) {
+		t.Fatal("the parsed adaptor signature should be valid")
+	}
+
+	if _, err := ecdsa.ParseAdaptorSignature(curve, data[1:]); nil == err {
+		t.Fatal("the truncated adaptor signature should be rejected")
+	}
+
+	// SHat = 0
+	zero := append([]byte{}, data...)
+	for i := 66; i < 98; i++ {
+		zero[i] = 0
+	}
+	if _, err := ecdsa.ParseAdaptorSignature(curve, zero); nil == err {
+		t.Fatal("the zero SHat should be rejected")
+	}
+
+	// the x coordinate of R at P
+	bad := append([]byte{}, data...)
+	copy(bad[1:33], curve.Params().P.Bytes())
+	if _, err := ecdsa.ParseAdaptorSignature(curve, bad); nil == err {
+		t.Fatal("the invalid R should be rejected")
+	}
+}
@@ -0,0 +1,271 @@
+package ecdsa
+
+import (
+	"crypto/rand"
+	"math/big"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sammy00/crypto/elliptic"
+)
+
+// batchLeafLen is the max number of items verified one by one during the
+// bisection of a failing batch
+const batchLeafLen = 2
+
+// batchChunkLen is the number of consecutive items handled by a worker at a
+// time, which bounds the size of each multi-scalar multiplication and of its
+// tables regardless of the total number of items
+const batchChunkLen = 128
+
+// batchCoefficientLen is the length in bytes of the random coefficients of
+// the linear combination of a batch, which bounds the chance that an invalid
+// batch passes by 2^-128
+const batchCoefficientLen = 16
+
+// BatchItem is a signature (r,s) of hash under the public key to verify in a
+// batch. The batch verification needs to recover the point R, so the
+// recovery id as of RecoverPublicKey should be given with HasRecoveryID set.
+// Otherwise, the item is verified on its own. A wrong recovery id never
+// turns a valid signature invalid, but costs the speed-up of batching.
+type BatchItem struct {
+	PublicKey *PublicKey
+	Hash      []byte
+	R, S      *big.Int
+
+	// RecoveryID is only taken into account if HasRecoveryID is true, so
+	// that the zero value of an item means an unknown recovery id
+	RecoveryID    int
+	HasRecoveryID bool
+}
+
+// batchEntry is a BatchItem prepared for the batch equation
+//
+//	sum_i z_i*(u1_i*G + u2_i*Q_i - R_i) = O
+type batchEntry struct {
+	index  int
+	u1, u2 *big.Int
+	// the public key Q
+	qx, qy *big.Int
+	// the point -R
+	rx, ry *big.Int
+}
+
+// VerifyBatch verifies the items all at once, and returns the indices of the
+// invalid ones in ascending order, which is nil if all are valid. An item is
+// regarded as valid if and only if Verify accepts it.
+//
+// The items are handled in chunks of consecutive ones by a pool of
+// runtime.GOMAXPROCS(0) workers. Within a chunk, the items over the same
+// curve with a cofactor of 1 and the recovery ids are checked by a random
+// linear combination of their verification equations, evaluated by one
+// multi-scalar multiplication, and a failing batch is bisected to identify
+// the invalid items. The others are verified one by one.
+func VerifyBatch(items []BatchItem) []int {
+	nChunks := (len(items) + batchChunkLen - 1) / batchChunkLen
+	results := make([][]int, nChunks)
+
+	verifyChunk := func(i int) {
+		lo, hi := i*batchChunkLen, (i+1)*batchChunkLen
+		if hi > len(items) {
+			hi = len(items)
+		}
+		results[i] = verifyBatchChunk(items, lo, hi)
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > nChunks {
+		workers = nChunks
+	}
+
+	if workers <= 1 {
+		for i := 0; i < nChunks; i++ {
+			verifyChunk(i)
+		}
+	} else {
+		var (
+			wg   sync.WaitGroup
+			next int64 = -1
+		)
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := int(atomic.AddInt64(&next, 1)); i < nChunks; i = int(atomic.AddInt64(&next, 1)) {
+					verifyChunk(i)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	var invalid []int
+	for _, indices := range results {
+		invalid = append(invalid, indices...)
+	}
+
+	return invalid
+}
+
+// verifyBatchChunk returns the indices of the invalid ones among items[lo:hi]
+// in ascending order
+func verifyBatchChunk(items []BatchItem, lo, hi int) []int {
+	var invalid []int
+
+	// group the entries by curves, and verify the rest individually
+	groups := make(map[elliptic.Curve][]*batchEntry)
+	for i := lo; i < hi; i++ {
+		entry, ok := prepareBatchEntry(&items[i], i)
+		switch {
+		case ok:
+			c := items[i].PublicKey.Curve
+			groups[c] = append(groups[c], entry)
+		case !verifyBatchItem(&items[i]):
+			invalid = append(invalid, i)
+		}
+	}
+
+	for c, entries := range groups {
+		invalid = append(invalid, bisectBatch(c, items, entries)...)
+	}
+	sort.Ints(invalid)
+
+	return invalid
+}
+
+// prepareBatchEntry prepares the item for the batch equation, and reports
+// whether it can join a batch at all
+func prepareBatchEntry(item *BatchItem, index int) (*batchEntry, bool) {
+	if (nil == item.PublicKey) || !item.HasRecoveryID ||
+		(item.RecoveryID < 0) || (item.RecoveryID > 3) {
+		return nil, false
+	}
+
+	c := item.PublicKey.Curve
+	params := c.Params()
+	N := params.N
+	if (nil != params.H) && (1 != params.H.BitLen()) {
+		return nil, false
+	}
+
+	r, s := item.R, item.S
+	if (r.Sign() <= 0) || (s.Sign() <= 0) || (r.Cmp(N) >= 0) || (s.Cmp(N) >= 0) {
+		return nil, false
+	}
+
+	// R = (r + j*N, y) with the parity of y told by the recovery id
+	rx := new(big.Int).Set(r)
+	if 0 != item.RecoveryID&2 {
+		rx.Add(rx, N)
+	}
+	if rx.Cmp(params.P) >= 0 {
+		return nil, false
+	}
+	ry, err := c.DecompressPoint(rx, 0 != item.RecoveryID&1)
+	if nil != err {
+		return nil, false
+	}
+
+	// u1 = e*s^{-1}, u2 = r*s^{-1}
+	var w *big.Int
+	if in, ok := c.(invertible); ok {
+		w = in.Inverse(s)
+	} else {
+		w = new(big.Int).ModInverse(s, N)
+	}
+	u1 := hashToInt(item.Hash, c)
+	u1.Mul(u1, w)
+	u1.Mod(u1, N)
+	u2 := w.Mul(r, w)
+	u2.Mod(u2, N)
+
+	return &batchEntry{
+		index: index,
+		u1:    u1,
+		u2:    u2,
+		qx:    item.PublicKey.X,
+		qy:    item.PublicKey.Y,
+		rx:    rx,
+		ry:    ry.Sub(params.P, ry),
+	}, true
+}
+
+// bisectBatch returns the indices of the invalid items among the entries,
+// bisecting the batch if it fails as a whole
+func bisectBatch(c elliptic.Curve, items []BatchItem, entries []*batchEntry) []int {
+	if len(entries) <= batchLeafLen {
+		var invalid []int
+		for _, e := range entries {
+			if !verifyBatchItem(&items[e.index]) {
+				invalid = append(invalid, e.index)
+			}
+		}
+
+		return invalid
+	}
+
+	if verifyBatchEntries(c, entries) {
+		return nil
+	}
+
+	half := len(entries) / 2
+	return append(bisectBatch(c, items, entries[:half]),
+		bisectBatch(c, items, entries[half:])...)
+}
+
+// verifyBatchEntries checks the batch equation with random coefficients z_i
+//
+//	(sum_i z_i*u1_i)*G + sum_i (z_i*u2_i)*Q_i + sum_i z_i*(-R_i) = O
+func verifyBatchEntries(c elliptic.Curve, entries []*batchEntry) bool {
+	params := c.Params()
+	N := params.N
+
+	n := len(entries)
+	xs := make([]*big.Int, 0, 2*n+1)
+	ys := make([]*big.Int, 0, 2*n+1)
+	scalars := make([][]byte, 0, 2*n+1)
+
+	coefficients := make([]byte, n*batchCoefficientLen)
+	if _, err := rand.Read(coefficients); nil != err {
+		return false
+	}
+
+	g := new(big.Int)
+	for i, e := range entries {
+		z := new(big.Int).SetBytes(coefficients[i*batchCoefficientLen : (i+1)*batchCoefficientLen])
+		if 0 == i {
+			// the first coefficient can be 1 without any loss
+			z.SetInt64(1)
+		}
+
+		// sum_i z_i*u1_i
+		g.Add(g, new(big.Int).Mul(z, e.u1))
+
+		// z_i*u2_i
+		u2 := new(big.Int).Mul(z, e.u2)
+		u2.Mod(u2, N)
+
+		xs = append(xs, e.qx, e.rx)
+		ys = append(ys, e.qy, e.ry)
+		scalars = append(scalars, u2.Bytes(), z.Bytes())
+	}
+	g.Mod(g, N)
+
+	xs = append(xs, params.Gx)
+	ys = append(ys, params.Gy)
+	scalars = append(scalars, g.Bytes())
+
+	x, y := elliptic.MultiScalarMult(c, xs, ys, scalars)
+	return (0 == x.Sign()) && (0 == y.Sign())
+}
+
+// verifyBatchItem verifies the item on its own
+func verifyBatchItem(item *BatchItem) bool {
+	if nil == item.PublicKey {
+		return false
+	}
+
+	return Verify(item.PublicKey, item.Hash, item.R, item.S)
+}
@@ -0,0 +1,146 @@
+package ecdsa_test
+
+import (
+	stdElliptic "crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+)
+
+// batchItems signs n digests over the curve with the recovery ids
+func batchItems(tb testing.TB, curve elliptic.Curve, n int) []ecdsa.BatchItem {
+	byteLen := (curve.Params().BitSize + 7) / 8
+
+	items := make([]ecdsa.BatchItem, n)
+	for i := range items {
+		priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if nil != err {
+			tb.Fatal(err)
+		}
+		digest := sha256.Sum256([]byte{byte(i), byte(i >> 8)})
+
+		sig, err := ecdsa.SignCompact(priv, digest[:], false)
+		if nil != err {
+			tb.Fatal(err)
+		}
+
+		items[i] = ecdsa.BatchItem{
+			PublicKey:     &priv.PublicKey,
+			Hash:          digest[:],
+			R:             new(big.Int).SetBytes(sig[1 : 1+byteLen]),
+			S:             new(big.Int).SetBytes(sig[1+byteLen:]),
+			RecoveryID:    int(sig[0]-27) & 3,
+			HasRecoveryID: true,
+		}
+	}
+
+	return items
+}
+
+func TestVerifyBatch(t *testing.T) {
+	curves := map[string]elliptic.Curve{
+		"secp256k1": elliptic.P256k1(),
+		"P-256":     elliptic.FromStd(stdElliptic.P256()),
+	}
+
+	for name, curve := range curves {
+		t.Run(name, func(t *testing.T) {
+			items := batchItems(t, curve, 48)
+			if invalid := ecdsa.VerifyBatch(items); nil != invalid {
+				t.Fatalf("all items should be valid: got invalid %v", invalid)
+			}
+
+			// unknown or wrong recovery ids never invalidate a signature
+			items[3].HasRecoveryID = false
+			items[5].RecoveryID = -1
+			items[7].RecoveryID ^= 1
+			if invalid := ecdsa.VerifyBatch(items); nil != invalid {
+				t.Fatalf("all items should be valid: got invalid %v", invalid)
+			}
+
+			// tamper some items
+			items[0].Hash = []byte("another digest")
+			items[3].S = new(big.Int).Add(items[3].S, big.NewInt(1))
+			items[20].R = items[21].R
+			items[47].PublicKey = items[46].PublicKey
+
+			expected := []int{0, 3, 20, 47}
+			if invalid := ecdsa.VerifyBatch(items); !reflect.DeepEqual(invalid, expected) {
+				t.Fatalf("invalid items: got %v, want %v", invalid, expected)
+			}
+		})
+	}
+}
+
+func TestVerifyBatchMixed(t *testing.T) {
+	items := append(batchItems(t, elliptic.P256k1(), 8),
+		batchItems(t, elliptic.FromStd(stdElliptic.P256()), 8)...)
+
+	// out-of-range values and missing keys are rejected
+	items[2].S = new(big.Int)
+	items[9].R = items[9].PublicKey.Params().N
+	items[12].PublicKey = nil
+
+	expected := []int{2, 9, 12}
+	if invalid := ecdsa.VerifyBatch(items); !reflect.DeepEqual(invalid, expected) {
+		t.Fatalf("invalid items: got %v, want %v", invalid, expected)
+	}
+
+	if invalid := ecdsa.VerifyBatch(nil); nil != invalid {
+		t.Fatalf("an empty batch should be valid: got invalid %v", invalid)
+	}
+}
+
+func TestVerifyBatchChunks(t *testing.T) {
+	// more items than a chunk, some without the recovery ids
+	items := batchItems(t, elliptic.P256k1(), 300)
+	for i := 0; i < len(items); i += 3 {
+		items[i] = ecdsa.BatchItem{PublicKey: items[i].PublicKey, Hash: items[i].Hash,
+			R: items[i].R, S: items[i].S}
+	}
+	if invalid := ecdsa.VerifyBatch(items); nil != invalid {
+		t.Fatalf("all items should be valid: got invalid %v", invalid)
+	}
+
+	expected := []int{0, 127, 128, 129, 256, 299}
+	for _, i := range expected {
+		items[i].Hash = []byte("another digest")
+	}
+	if invalid := ecdsa.VerifyBatch(items); !reflect.DeepEqual(invalid, expected) {
+		t.Fatalf("invalid items: got %v, want %v", invalid, expected)
+	}
+}
+
+func BenchmarkVerifyBatch(b *testing.B) {
+	items := batchItems(b, elliptic.P256k1(), 256)
+
+	b.Run("Batch", func(bb *testing.B) {
+		for i := 0; i < bb.N; i++ {
+			ecdsa.VerifyBatch(items)
+		}
+	})
+	b.Run("WithoutRecoveryID", func(bb *testing.B) {
+		plain := make([]ecdsa.BatchItem, len(items))
+		for i, item := range items {
+			plain[i] = ecdsa.BatchItem{PublicKey: item.PublicKey, Hash: item.Hash,
+				R: item.R, S: item.S}
+		}
+
+		bb.ResetTimer()
+		for i := 0; i < bb.N; i++ {
+			ecdsa.VerifyBatch(plain)
+		}
+	})
+	b.Run("OneByOne", func(bb *testing.B) {
+		for i := 0; i < bb.N; i++ {
+			for _, item := range items {
+				ecdsa.Verify(item.PublicKey, item.Hash, item.R, item.S)
+			}
+		}
+	})
+}
@@ -41,7 +41,7 @@ type PublicKeyParser interface {
 	// same concrete type.
 	// And the elliptic curve of the receiver will be initialised with the
 	// elliptic.Curve provided, which will helps to valid if the parsed point
-	// is on the curve
+	// is on the curve and lies in its prime-order subgroup
 	Parse(elliptic.Curve, []byte) error
 }
 
@@ -0,0 +1,191 @@
+package ecdsa
+
+// References:
+//   [BIP66]: Strict DER signatures,
+//     https://github.com/bitcoin/bips/blob/master/bip-0066.mediawiki
+
+import (
+	"encoding/asn1"
+	"errors"
+	"math/big"
+)
+
+const (
+	// asn1Sequence is the tag of the ASN.1 SEQUENCE of (r,s)
+	asn1Sequence byte = 0x30
+	// asn1Integer is the tag of the ASN.1 INTEGER of r and s
+	asn1Integer byte = 0x02
+)
+
+// MarshalDERSignature encodes the signature (r,s) into the ASN.1 DER form,
+// which is the same as PrivateKey.Sign outputs
+func MarshalDERSignature(r, s *big.Int) ([]byte, error) {
+	return asn1.Marshal(ecdsaSignature{r, s})
+}
+
+// ParseDERSignature decodes the signature (r,s) in the ASN.1 DER form with
+// the strict rules of [BIP66]: both lengths and integers must be minimally
+// encoded, the integers must not be negative, and there must be no trailing
+// bytes. Lengths beyond 127 bytes are allowed in the long form as is needed
+// by curves such as P-521.
+func ParseDERSignature(sig []byte) (r, s *big.Int, err error) {
+	if (len(sig) < 2) || (asn1Sequence != sig[0]) {
+		return nil, nil, errors.New("ecdsa: malformed DER signature: no sequence")
+	}
+
+	body, rest, err := readStrictLength(sig[1:])
+	if nil != err {
+		return nil, nil, err
+	}
+	if 0 != len(rest) {
+		return nil, nil, errors.New("ecdsa: malformed DER signature: trailing bytes")
+	}
+
+	if r, body, err = readStrictInteger(body); nil != err {
+		return nil, nil, err
+	}
+	if s, body, err = readStrictInteger(body); nil != err {
+		return nil, nil, err
+	}
+	if 0 != len(body) {
+		return nil, nil, errors.New("ecdsa: malformed DER signature: trailing bytes in sequence")
+	}
+
+	return r, s, nil
+}
+
+// ParseSignatureLax decodes the signature (r,s) in the legacy BER-like forms
+// accepted by early bitcoin nodes, as the ecdsa_signature_parse_der_lax of
+// bitcoin core does. The length of the sequence is ignored, lengths may be
+// padded with zeros in the long form, integers are read as unsigned with
+// any padding, and trailing bytes are ignored. It should be used for legacy
+// data only.
+func ParseSignatureLax(sig []byte) (r, s *big.Int, err error) {
+	if (len(sig) < 2) || (asn1Sequence != sig[0]) {
+		return nil, nil, errors.New("ecdsa: malformed signature: no sequence")
+	}
+
+	// skip the length of the sequence
+	body := sig[1:]
+	if lenByte := body[0]; lenByte&0x80 != 0 {
+		n := int(lenByte & 0x7f)
+		if n > len(body)-1 {
+			return nil, nil, errors.New("ecdsa: malformed signature: truncated length")
+		}
+		body = body[1+n:]
+	} else {
+		body = body[1:]
+	}
+
+	if r, body, err = readLaxInteger(body); nil != err {
+		return nil, nil, err
+	}
+	if s, _, err = readLaxInteger(body); nil != err {
+		return nil, nil, err
+	}
+
+	return r, s, nil
+}
+
+// VerifyASN1 verifies the signature in the strict DER form of hash using the
+// public key, pub
+func VerifyASN1(pub *PublicKey, hash, sig []byte) bool {
+	r, s, err := ParseDERSignature(sig)
+	if nil != err {
+		return false
+	}
+
+	return Verify(pub, hash, r, s)
+}
+
+// readStrictLength reads the minimally-encoded length of the content in
+// data, and returns the content and the bytes after it
+func readStrictLength(data []byte) (content, rest []byte, err error) {
+	if 0 == len(data) {
+		return nil, nil, errors.New("ecdsa: malformed DER signature: no length")
+	}
+
+	length, data := int(data[0]), data[1:]
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		// lengths of signatures fit within 2 bytes
+		if (0 == n) || (n > 2) || (n > len(data)) || (0 == data[0]) {
+			return nil, nil, errors.New("ecdsa: malformed DER signature: non-minimal length")
+		}
+
+		length = 0
+		for _, b := range data[:n] {
+			length = length<<8 | int(b)
+		}
+		data = data[n:]
+
+		if length < 0x80 {
+			return nil, nil, errors.New("ecdsa: malformed DER signature: non-minimal length")
+		}
+	}
+
+	if length > len(data) {
+		return nil, nil, errors.New("ecdsa: malformed DER signature: truncated content")
+	}
+
+	return data[:length], data[length:], nil
+}
+
+// readStrictInteger reads the minimally-encoded non-negative INTEGER
+func readStrictInteger(data []byte) (x *big.Int, rest []byte, err error) {
+	if (0 == len(data)) || (asn1Integer != data[0]) {
+		return nil, nil, errors.New("ecdsa: malformed DER signature: no integer")
+	}
+
+	content, rest, err := readStrictLength(data[1:])
+	if nil != err {
+		return nil, nil, err
+	}
+
+	switch {
+	case 0 == len(content):
+		return nil, nil, errors.New("ecdsa: malformed DER signature: empty integer")
+	case content[0]&0x80 != 0:
+		return nil, nil, errors.New("ecdsa: malformed DER signature: negative integer")
+	case (len(content) > 1) && (0 == content[0]) && (0 == content[1]&0x80):
+		return nil, nil, errors.New("ecdsa: malformed DER signature: padded integer")
+	}
+
+	return new(big.Int).SetBytes(content), rest, nil
+}
+
+// readLaxInteger reads the INTEGER with the lax rules of ParseSignatureLax
+func readLaxInteger(data []byte) (x *big.Int, rest []byte, err error) {
+	if (len(data) < 2) || (asn1Integer != data[0]) {
+		return nil, nil, errors.New("ecdsa: malformed signature: no integer")
+	}
+
+	length, data := int(data[1]), data[2:]
+	if length&0x80 != 0 {
+		n := length & 0x7f
+		if n > len(data) {
+			return nil, nil, errors.New("ecdsa: malformed signature: truncated length")
+		}
+		lengthBytes := data[:n]
+		data = data[n:]
+
+		// skip the zero padding of the length
+		for (len(lengthBytes) > 0) && (0 == lengthBytes[0]) {
+			lengthBytes = lengthBytes[1:]
+		}
+		if len(lengthBytes) > 3 {
+			return nil, nil, errors.New("ecdsa: malformed signature: overlong length")
+		}
+
+		length = 0
+		for _, b := range lengthBytes {
+			length = length<<8 | int(b)
+		}
+	}
+
+	if length > len(data) {
+		return nil, nil, errors.New("ecdsa: malformed signature: truncated integer")
+	}
+
+	return new(big.Int).SetBytes(data[:length]), data[length:], nil
+}
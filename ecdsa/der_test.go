@@ -0,0 +1,127 @@
+package ecdsa_test
+
+import (
+	"crypto"
+	stdElliptic "crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+)
+
+// derTest is a signature with r = 0x11 and s = 0x22 in various encodings
+type derTest struct {
+	name   string
+	sig    string
+	strict bool
+	lax    bool
+}
+
+var derTestVec = []derTest{
+	{"valid", "3006020111020122", true, true},
+	{"valid with padded high bit", "300702011102020080", true, true},
+	{"not a sequence", "3106020111020122", false, false},
+	{"sequence too long", "3007020111020122", false, true},
+	{"sequence too short", "3005020111020122", false, true},
+	{"non-minimal sequence length", "308106020111020122", false, true},
+	{"trailing bytes", "300602011102012200", false, true},
+	{"trailing bytes in sequence", "300702011102012200", false, true},
+	{"not an integer", "3006030111020122", false, false},
+	{"empty r", "30050200020122", false, true},
+	{"negative r", "3006020191020122", false, true},
+	{"padded r", "300702020011020122", false, true},
+	{"non-minimal length of r", "300702810111020122", false, true},
+	{"padded length of s", "30080201110282000122", false, true},
+	{"truncated s", "3006020111020222", false, false},
+}
+
+func TestParseDERSignature(t *testing.T) {
+	for _, c := range derTestVec {
+		sig, _ := hex.DecodeString(c.sig)
+
+		r, s, err := ecdsa.ParseDERSignature(sig)
+		if c.strict != (nil == err) {
+			t.Fatalf("%s: invalid strict parsing: got error %v", c.name, err)
+		}
+		if c.strict && ((0 == r.Sign()) || (0 == s.Sign())) {
+			t.Fatalf("%s: invalid values: got (%x,%x)", c.name, r, s)
+		}
+
+		r, s, err = ecdsa.ParseSignatureLax(sig)
+		if c.lax != (nil == err) {
+			t.Fatalf("%s: invalid lax parsing: got error %v", c.name, err)
+		}
+		if c.lax && (nil == r || nil == s) {
+			t.Fatalf("%s: missing values", c.name)
+		}
+	}
+}
+
+func TestParseSignatureLaxValues(t *testing.T) {
+	// padded r and negative s are read as unsigned
+	sig, _ := hex.DecodeString("30080203000011020191")
+
+	r, s, err := ecdsa.ParseSignatureLax(sig)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if (0 != r.Cmp(big.NewInt(0x11))) || (0 != s.Cmp(big.NewInt(0x91))) {
+		t.Fatalf("invalid values: got (%x,%x), want (11,91)", r, s)
+	}
+}
+
+func TestVerifyASN1(t *testing.T) {
+	curves := map[string]elliptic.Curve{
+		"secp256k1": elliptic.P256k1(),
+		// whose signatures need the long form of lengths
+		"P-521": elliptic.FromStd(stdElliptic.P521()),
+	}
+
+	for name, curve := range curves {
+		t.Run(name, func(t *testing.T) {
+			priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+			if nil != err {
+				t.Fatal(err)
+			}
+			digest := sha256.Sum256([]byte("testing"))
+
+			sig, err := priv.Sign(rand.Reader, digest[:], crypto.SHA256)
+			if nil != err {
+				t.Fatal(err)
+			}
+			if !ecdsa.VerifyASN1(&priv.PublicKey, digest[:], sig) {
+				t.Fatal("the signature should be valid")
+			}
+
+			r, s, err := ecdsa.ParseDERSignature(sig)
+			if nil != err {
+				t.Fatal(err)
+			}
+			if !ecdsa.Verify(&priv.PublicKey, digest[:], r, s) {
+				t.Fatal("the parsed signature should be valid")
+			}
+
+			der, err := ecdsa.MarshalDERSignature(r, s)
+			if nil != err {
+				t.Fatal(err)
+			}
+			if string(der) != string(sig) {
+				t.Fatalf("invalid encoding: got %x, want %x", der, sig)
+			}
+
+			// trailing bytes are rejected
+			if ecdsa.VerifyASN1(&priv.PublicKey, digest[:], append(sig, 0x00)) {
+				t.Fatal("the signature with trailing bytes should be invalid")
+			}
+
+			digest[0] ^= 0xff
+			if ecdsa.VerifyASN1(&priv.PublicKey, digest[:], sig) {
+				t.Fatal("the signature of another digest should be invalid")
+			}
+		})
+	}
+}
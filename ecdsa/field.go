@@ -7,9 +7,6 @@ import (
 	"github.com/sammy00/crypto/elliptic"
 )
 
-// one is the identity element in the field
-var one = new(big.Int).SetInt64(1)
-
 // fermatInverse calculates the inverse of k in GF(P) using Fermat's method.
 // This has better constant-time properties than Euclid's method (implemented
 // in math/big.Int.ModInverse) although math/big itself isn't strictly
@@ -41,19 +38,8 @@ func hashToInt(hash []byte, c elliptic.Curve) *big.Int {
 	return ret
 }
 
-// randFieldElement returns a random element of the field underlying the given
-// curve using the procedure given in [NSA] A.2.1.
+// randFieldElement returns a random element in [1,N-1] of the given curve
+// using the procedure given in A.2.1 of FIPS 186-5, a.k.a. [NSA] A.2.1.
 func randFieldElement(c elliptic.Curve, rand io.Reader) (*big.Int, error) {
-	params := c.Params()
-	b := make([]byte, params.BitSize/8+8)
-	if _, err := io.ReadFull(rand, b); nil != err {
-		return nil, err
-	}
-
-	k := new(big.Int).SetBytes(b)
-	n := new(big.Int).Sub(params.N, one)
-	k.Mod(k, n)
-	k.Add(k, one)
-
-	return k, nil
+	return elliptic.RandScalar(c, rand, elliptic.ExtraRandomBits)
 }
@@ -0,0 +1,47 @@
+package ecdsa
+
+// References:
+//   [BIP62]: Dealing with malleability,
+//     https://github.com/bitcoin/bips/blob/master/bip-0062.mediawiki
+//   [BIP146]: Dealing with signature encoding malleability,
+//     https://github.com/bitcoin/bips/blob/master/bip-0146.mediawiki
+
+import (
+	"math/big"
+
+	"github.com/sammy00/crypto/elliptic"
+)
+
+// IsLowS checks if s is at most N/2, which is the canonical one of the two
+// valid s values of a signature, namely s and N-s
+func IsLowS(c elliptic.Curve, s *big.Int) bool {
+	halfN := new(big.Int).Rsh(c.Params().N, 1)
+	return s.Cmp(halfN) <= 0
+}
+
+// NormalizeS returns the low s of the two valid values s and N-s, so that the
+// signature (r,s) can no longer be mauled by negating s
+func NormalizeS(c elliptic.Curve, s *big.Int) *big.Int {
+	if IsLowS(c, s) {
+		return new(big.Int).Set(s)
+	}
+
+	return new(big.Int).Sub(c.Params().N, s)
+}
+
+// VerifyStrict verifies the signature as Verify does, but rejects the high s
+// as is required by the LOW_S rule of [BIP62] and [BIP146], as well as by
+// Ethereum since the Homestead fork.
+func VerifyStrict(pub *PublicKey, hash []byte, r, s *big.Int) bool {
+	if !IsLowS(pub.Curve, s) {
+		return false
+	}
+
+	return Verify(pub, hash, r, s)
+}
+
+// prefersLowS tells if the signatures over the curve should have a low s by
+// default, which is the case for secp256k1
+func prefersLowS(c elliptic.Curve) bool {
+	return c == elliptic.P256k1()
+}
@@ -0,0 +1,96 @@
+package ecdsa_test
+
+import (
+	"crypto"
+	stdElliptic "crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+)
+
+func TestNormalizeS(t *testing.T) {
+	curve := elliptic.P256k1()
+	N := curve.Params().N
+	halfN := new(big.Int).Rsh(N, 1)
+
+	testCases := []struct {
+		s, expect *big.Int
+	}{
+		{big.NewInt(1), big.NewInt(1)},
+		{halfN, halfN},
+		{new(big.Int).Add(halfN, big.NewInt(1)), halfN},
+		{new(big.Int).Sub(N, big.NewInt(1)), big.NewInt(1)},
+	}
+
+	for i, c := range testCases {
+		if got := ecdsa.NormalizeS(curve, c.s); 0 != got.Cmp(c.expect) {
+			t.Fatalf("#%d invalid s: got %x, want %x", i, got, c.expect)
+		}
+		if !ecdsa.IsLowS(curve, c.expect) {
+			t.Fatalf("#%d %x should be low", i, c.expect)
+		}
+	}
+}
+
+func TestSignLowS(t *testing.T) {
+	curve := elliptic.P256k1()
+	N := curve.Params().N
+
+	priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 64; i++ {
+		digest := sha256.Sum256([]byte{byte(i)})
+
+		r, s, err := ecdsa.Sign(rand.Reader, priv, digest[:])
+		if nil != err {
+			t.Fatal(err)
+		}
+		if !ecdsa.IsLowS(curve, s) {
+			t.Fatalf("secp256k1 signatures should have low s: got %x", s)
+		}
+		if !ecdsa.VerifyStrict(&priv.PublicKey, digest[:], r, s) {
+			t.Fatal("the low-s signature should pass the strict verification")
+		}
+
+		// the mauled signature passes the lax verification only
+		highS := new(big.Int).Sub(N, s)
+		if !ecdsa.Verify(&priv.PublicKey, digest[:], r, highS) {
+			t.Fatal("the high-s signature should be valid")
+		}
+		if ecdsa.VerifyStrict(&priv.PublicKey, digest[:], r, highS) {
+			t.Fatal("the high-s signature should fail the strict verification")
+		}
+	}
+}
+
+func TestSignHighSOnOtherCurves(t *testing.T) {
+	// the signatures over P-256 are kept as they are, so the RFC 6979
+	// vectors with a high s still hold
+	curve := elliptic.FromStd(stdElliptic.P256())
+	priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	var highS bool
+	for i := 0; (i < 256) && !highS; i++ {
+		digest := sha256.Sum256([]byte{byte(i)})
+
+		_, s, err := ecdsa.SignDeterministic(priv, digest[:], crypto.SHA256)
+		if nil != err {
+			t.Fatal(err)
+		}
+		highS = !ecdsa.IsLowS(curve, s)
+	}
+
+	if !highS {
+		t.Fatal("some signatures over P-256 should have a high s")
+	}
+}
@@ -0,0 +1,96 @@
+package ecdsa
+
+import (
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+)
+
+var (
+	// errDigestLength is returned if the digest mismatches the claimed hash
+	errDigestLength = errors.New("ecdsa: the digest length mismatches the hash function")
+	// errNoHash is returned if no hash function is given to hash a message
+	errNoHash = errors.New("ecdsa: no hash function is specified")
+	// errHashConflict is returned if both Keccak and Hash are specified
+	errHashConflict = errors.New("ecdsa: Keccak and Hash are mutually exclusive")
+)
+
+// newHash instantiates the hash function selected by opts
+func (opts *SignerOpts) newHash() (hash.Hash, error) {
+	switch {
+	case opts.Keccak && (0 != opts.Hash):
+		return nil, errHashConflict
+	case opts.Keccak:
+		return sha3.NewLegacyKeccak256(), nil
+	case 0 == opts.Hash:
+		return nil, errNoHash
+	case !opts.Hash.Available():
+		return nil, errHashUnavailable
+	}
+
+	return opts.Hash.New(), nil
+}
+
+// digestSize returns the digest length of the hash function selected by
+// opts, or 0 if none is selected
+func (opts *SignerOpts) digestSize() int {
+	switch {
+	case opts.Keccak:
+		return 32
+	case 0 == opts.Hash:
+		return 0
+	}
+
+	return opts.Hash.Size()
+}
+
+// digestMessage hashes all the message read from msg with the hash function
+// selected by opts
+func digestMessage(msg io.Reader, opts *SignerOpts) ([]byte, error) {
+	if nil == opts {
+		return nil, errNoHash
+	}
+
+	d, err := opts.newHash()
+	if nil != err {
+		return nil, err
+	}
+
+	if _, err := io.Copy(d, msg); nil != err {
+		return nil, err
+	}
+
+	return d.Sum(nil), nil
+}
+
+// SignMessage hashes the whole message read from msg with the hash function
+// selected by opts, e.g., &SignerOpts{Hash: crypto.SHA256} or
+// &SignerOpts{Keccak: true}, and signs the digest with SignWithOpts. The
+// hash function must be linked into the binary, such as by importing
+// crypto/sha256 or golang.org/x/crypto/sha3.
+func SignMessage(rand io.Reader, priv *PrivateKey, msg io.Reader,
+	opts *SignerOpts) (r, s *big.Int, err error) {
+	digest, err := digestMessage(msg, opts)
+	if nil != err {
+		return nil, nil, err
+	}
+
+	return SignWithOpts(rand, priv, digest, opts)
+}
+
+// VerifyMessage hashes the whole message read from msg with the hash function
+// selected by opts, and verifies the signature (r,s) of the digest by pub.
+// The error is only about hashing the message, in which case the signature
+// is reported as invalid.
+func VerifyMessage(pub *PublicKey, msg io.Reader, opts *SignerOpts,
+	r, s *big.Int) (bool, error) {
+	digest, err := digestMessage(msg, opts)
+	if nil != err {
+		return false, err
+	}
+
+	return Verify(pub, digest, r, s), nil
+}
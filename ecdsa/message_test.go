@@ -0,0 +1,168 @@
+package ecdsa_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	_ "crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"golang.org/x/crypto/sha3"
+)
+
+// failingReader fails after handing out n bytes
+type failingReader struct{ n int }
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if 0 == r.n {
+		return 0, errors.New("broken reader")
+	}
+	if len(p) > r.n {
+		p = p[:r.n]
+	}
+	r.n -= len(p)
+
+	return len(p), nil
+}
+
+func TestSignMessage(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256k1(), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	// long enough to be streamed in several chunks
+	msg := bytes.Repeat([]byte("streaming input "), 4096)
+
+	hashes := map[string]*ecdsa.SignerOpts{
+		"SHA-256":    {Hash: crypto.SHA256},
+		"SHA-512":    {Hash: crypto.SHA512},
+		"SHA3-256":   {Hash: crypto.SHA3_256},
+		"Keccak-256": {Keccak: true},
+	}
+
+	for name, h := range hashes {
+		t.Run(name, func(t *testing.T) {
+			r, s, err := ecdsa.SignMessage(rand.Reader, priv, bytes.NewReader(msg), h)
+			if nil != err {
+				t.Fatal(err)
+			}
+
+			ok, err := ecdsa.VerifyMessage(&priv.PublicKey, bytes.NewReader(msg), h, r, s)
+			if nil != err {
+				t.Fatal(err)
+			} else if !ok {
+				t.Fatal("the signature should be valid")
+			}
+
+			tampered := append([]byte{}, msg...)
+			tampered[len(tampered)-1] ^= 0x01
+			if ok, _ := ecdsa.VerifyMessage(&priv.PublicKey, bytes.NewReader(tampered),
+				h, r, s); ok {
+				t.Fatal("the signature should be invalid for another message")
+			}
+		})
+	}
+
+	// the signature must be over the digest of the expected hash function
+	r, s, err := ecdsa.SignMessage(rand.Reader, priv, bytes.NewReader(msg),
+		&ecdsa.SignerOpts{Keccak: true})
+	if nil != err {
+		t.Fatal(err)
+	}
+	if !ecdsa.Verify(&priv.PublicKey, keccak256(msg), r, s) {
+		t.Fatal("the signature should be over the Keccak-256 digest")
+	}
+	if ok, _ := ecdsa.VerifyMessage(&priv.PublicKey, bytes.NewReader(msg),
+		&ecdsa.SignerOpts{Hash: crypto.SHA3_256}, r, s); ok {
+		t.Fatal("Keccak-256 and SHA3-256 should not be mixed up")
+	}
+}
+
+func TestSignMessageErrors(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256k1(), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	sha256Opts := &ecdsa.SignerOpts{Hash: crypto.SHA256}
+
+	if _, _, err := ecdsa.SignMessage(rand.Reader, priv, &failingReader{100},
+		sha256Opts); nil == err {
+		t.Fatal("the reading error should be reported")
+	}
+
+	// MD4 isn't linked into the binary
+	if _, _, err := ecdsa.SignMessage(rand.Reader, priv, strings.NewReader("hello"),
+		&ecdsa.SignerOpts{Hash: crypto.MD4}); nil == err {
+		t.Fatal("the unavailable hash should be reported")
+	}
+
+	if _, _, err := ecdsa.SignMessage(rand.Reader, priv, strings.NewReader("hello"),
+		nil); nil == err {
+		t.Fatal("the missing hash should be reported")
+	}
+
+	if _, _, err := ecdsa.SignMessage(rand.Reader, priv, strings.NewReader("hello"),
+		&ecdsa.SignerOpts{Hash: crypto.SHA256, Keccak: true}); nil == err {
+		t.Fatal("the conflicting hashes should be reported")
+	}
+
+	if _, err := ecdsa.VerifyMessage(&priv.PublicKey, &failingReader{100},
+		sha256Opts, priv.D, priv.D); nil == err {
+		t.Fatal("the reading error should be reported")
+	}
+}
+
+func TestKeccak256(t *testing.T) {
+	// Keccak-256 of the empty string, as is known to Ethereum
+	const want = "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"
+
+	if got := hex.EncodeToString(keccak256(nil)); got != want {
+		t.Fatalf("invalid digest: got %s, want %s", got, want)
+	}
+}
+
+func TestPrivateKeySignDigestLength(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256k1(), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name    string
+		digest  []byte
+		opts    crypto.SignerOpts
+		isValid bool
+	}{
+		{"SHA-256", make([]byte, 32), crypto.SHA256, true},
+		{"Keccak-256", make([]byte, 32), &ecdsa.SignerOpts{Keccak: true}, true},
+		{"Keccak-256 mismatch", make([]byte, 20), &ecdsa.SignerOpts{Keccak: true}, false},
+		{"unspecified hash", make([]byte, 20), &ecdsa.SignerOpts{}, true},
+		{"nil opts", make([]byte, 20), nil, true},
+		{"short digest", make([]byte, 20), crypto.SHA256, false},
+		{"long digest", make([]byte, 64), crypto.SHA256, false},
+		{"SHA-512 mismatch", make([]byte, 32), crypto.SHA512, false},
+	}
+
+	for _, c := range testCases {
+		_, err := priv.Sign(rand.Reader, c.digest, c.opts)
+		if c.isValid && (nil != err) {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		} else if !c.isValid && (nil == err) {
+			t.Errorf("%s: signing should fail", c.name)
+		}
+	}
+}
+
+func keccak256(msg []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(msg)
+
+	return h.Sum(nil)
+}
@@ -0,0 +1,51 @@
+//go:build linux
+// +build linux
+
+package ecdsa
+
+import (
+	"math/big"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// lockedMemory is an anonymous mapping locked by mlock(2), viewed as words.
+// It may be shared by copies of a private key, hence released only once.
+type lockedMemory struct {
+	mem   []byte
+	words []big.Word
+
+	released sync.Once
+}
+
+// lockMemory maps and locks the memory for n words
+func lockMemory(n int) (*lockedMemory, error) {
+	size := n * int(unsafe.Sizeof(big.Word(0)))
+
+	mem, err := syscall.Mmap(-1, 0, size, syscall.PROT_READ|syscall.PROT_WRITE,
+		syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	if nil != err {
+		return nil, err
+	}
+	if err := syscall.Mlock(mem); nil != err {
+		syscall.Munmap(mem)
+		return nil, err
+	}
+
+	words := (*[1 << 20]big.Word)(unsafe.Pointer(&mem[0]))[:n:n]
+
+	return &lockedMemory{mem: mem, words: words}, nil
+}
+
+// release zeroes, unlocks and unmaps the memory, doing nothing if called
+// again
+func (m *lockedMemory) release() {
+	m.released.Do(func() {
+		wipeBytes(m.mem)
+
+		syscall.Munlock(m.mem)
+		syscall.Munmap(m.mem)
+		m.mem, m.words = nil, nil
+	})
+}
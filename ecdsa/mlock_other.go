@@ -0,0 +1,22 @@
+//go:build !linux
+// +build !linux
+
+package ecdsa
+
+import (
+	"errors"
+	"math/big"
+)
+
+// lockedMemory is a placeholder as locking is unsupported
+type lockedMemory struct {
+	words []big.Word
+}
+
+// lockMemory always fails off Linux
+func lockMemory(n int) (*lockedMemory, error) {
+	return nil, errors.New("ecdsa: locking memory is unsupported on this platform")
+}
+
+// release does nothing
+func (m *lockedMemory) release() {}
@@ -15,15 +15,35 @@ package ecdsa
 //     http://www.secg.org/sec1-v2.pdf
 
 import (
+	"crypto"
+	"crypto/sha256"
+	"errors"
 	"io"
 	"math/big"
 
 	"github.com/sammy00/crypto/elliptic"
 )
 
-// GenerateKey generates a public and private key pair.
+// invertible is implemented by curves with a fast inverse modulo the group
+// order N
+type invertible interface {
+	// Inverse returns the inverse of k in GF(N)
+	Inverse(k *big.Int) *big.Int
+}
+
+// GenerateKey generates a public and private key pair by the method
+// elliptic.ExtraRandomBits of GenerateKeyWithMethod.
 func GenerateKey(c elliptic.Curve, rand io.Reader) (*PrivateKey, error) {
-	k, err := randFieldElement(c, rand)
+	return GenerateKeyWithMethod(c, rand, elliptic.ExtraRandomBits)
+}
+
+// GenerateKeyWithMethod generates a public and private key pair, whose
+// private scalar is drawn by the given method of appendix A.2 of FIPS 186-5.
+// The pair then has to pass the pairwise consistency test, i.e., a signature
+// by the private key must be verified by the public key.
+func GenerateKeyWithMethod(c elliptic.Curve, rand io.Reader,
+	method elliptic.KeyGenMethod) (*PrivateKey, error) {
+	k, err := elliptic.RandScalar(c, rand, method)
 	if nil != err {
 		return nil, err
 	}
@@ -34,30 +54,76 @@ func GenerateKey(c elliptic.Curve, rand io.Reader) (*PrivateKey, error) {
 	// pub = k*G
 	priv.PublicKey.X, priv.PublicKey.Y = c.ScalarBaseMult(k.Bytes())
 
+	if err := pairwiseConsistencyTest(priv); nil != err {
+		priv.Destroy()
+		return nil, err
+	}
+
 	return priv, nil
 }
 
+// pairwiseConsistencyTest signs a fixed digest with the deterministic nonce,
+// so as to consume no randomness, and verifies the signature with the public
+// key
+func pairwiseConsistencyTest(priv *PrivateKey) error {
+	digest := sha256.Sum256([]byte("ecdsa: pairwise consistency test"))
+
+	r, s, err := SignDeterministic(priv, digest[:], crypto.SHA256)
+	if nil != err {
+		return err
+	}
+	if !Verify(&priv.PublicKey, digest[:], r, s) {
+		return errors.New("ecdsa: the key pair fails the pairwise consistency test")
+	}
+
+	return nil
+}
+
 // Sign signs a hash (which should be the result of hashing a larger message)
 // using the private key, priv. If the hash is longer than the bit-length of the
 // private key's curve order, the hash will be truncated to that length.  It
 // returns the signature as a pair of integers. The security of the private key
-// depends on the entropy of rand.
+// depends on the entropy of rand. For secp256k1, s is always normalized to the
+// low one as NormalizeS does.
 func Sign(rand io.Reader, priv *PrivateKey, hash []byte) (r, s *big.Int, err error) {
+	return signWithNonce(priv, hash, func() (*big.Int, error) {
+		return randFieldElement(priv.Curve, rand)
+	})
+}
+
+// signWithNonce signs the hash with priv, drawing a fresh k from nonce each
+// time the signature turns out to be degenerate
+func signWithNonce(priv *PrivateKey, hash []byte,
+	nonce func() (*big.Int, error)) (r, s *big.Int, err error) {
 	c := priv.PublicKey.Curve
 	N := c.Params().N
 
+	if (nil == priv.D) || (0 == priv.D.Sign()) {
+		return nil, nil, errDestroyedKey
+	}
+
+	// k, k^{-1} and r*d would all leak the private key
+	var secrets secretBuffer
+	defer secrets.wipe()
+
 	var k, kInv *big.Int
 	for {
 		for {
-			k, err = randFieldElement(c, rand)
+			k, err = nonce()
 			if nil != err {
 				r, s = nil, nil
 				return
 			}
+			secrets.track(k)
+
+			if in, ok := c.(invertible); ok {
+				kInv = in.Inverse(k)
+			} else {
+				kInv = fermatInverse(k, N)
+			}
+			secrets.track(kInv)
 
-			//kInv = new(big.Int).ModInverse(k, N)
-			kInv = fermatInverse(k, N)
-			r, _ = c.ScalarBaseMult(k.Bytes())
+			r, _ = c.ScalarBaseMult(secrets.trackBytes(k.Bytes()))
 			r.Mod(r, N)
 			if 0 != r.Sign() {
 				break
@@ -67,16 +133,20 @@ func Sign(rand io.Reader, priv *PrivateKey, hash []byte) (r, s *big.Int, err err
 		// e = H(m)
 		e := hashToInt(hash, c)
 		// s = k^{-1}*(e+r*d)
-		s = new(big.Int).Mul(priv.D, r)
-		s.Add(s, e)
-		s.Mul(s, kInv)
-		s.Mod(s, N)
+		t := secrets.track(new(big.Int).Mul(priv.D, r))
+		t.Add(t, e)
+		t.Mul(t, kInv)
+		s = new(big.Int).Mod(t, N)
 
 		if 0 != s.Sign() {
 			break
 		}
 	}
 
+	if prefersLowS(c) {
+		s = NormalizeS(c, s)
+	}
+
 	return r, s, nil
 }
 
@@ -97,7 +167,12 @@ func Verify(pub *PublicKey, hash []byte, r, s *big.Int) bool {
 	// e = H(m)
 	e := hashToInt(hash, c)
 	// w = s^{-1}
-	w := new(big.Int).ModInverse(s, N)
+	var w *big.Int
+	if in, ok := c.(invertible); ok {
+		w = in.Inverse(s)
+	} else {
+		w = new(big.Int).ModInverse(s, N)
+	}
 	// u1 = e*w
 	u1 := e.Mul(e, w)
 	u1.Mod(u1, N)
@@ -3,35 +3,146 @@ package ecdsa
 import (
 	"crypto"
 	"encoding/asn1"
+	"errors"
 	"io"
 	"math/big"
+
+	"github.com/sammy00/crypto/elliptic"
 )
 
 // PrivateKey represents an ECDSA private key.
 type PrivateKey struct {
 	PublicKey
 	D *big.Int // private scalar
+
+	locked *lockedMemory // backing memory of D after Lock
 }
 
+// errDestroyedKey is returned when signing with a key without D, such as one
+// after Destroy
+var errDestroyedKey = errors.New("ecdsa: the private key is destroyed or zero")
+
 // ecdsaSignature assists in marshaling the signature
 type ecdsaSignature struct {
 	R, S *big.Int
 }
 
+// NewPrivateKey builds the private key of the scalar d over the curve, where
+// d must be in [1,N-1]
+func NewPrivateKey(c elliptic.Curve, d *big.Int) (*PrivateKey, error) {
+	if (nil == c) || (nil == d) {
+		return nil, errors.New("The private key is incomplete")
+	}
+	if (d.Sign() <= 0) || (d.Cmp(c.Params().N) >= 0) {
+		return nil, errors.New("The private key is out of range")
+	}
+
+	priv := &PrivateKey{D: new(big.Int).Set(d)}
+	priv.PublicKey.Curve = c
+	priv.PublicKey.X, priv.PublicKey.Y = c.ScalarBaseMult(d.Bytes())
+
+	return priv, nil
+}
+
+// Validate checks the private key is in [1,N-1], its public key passes the
+// full validation, and they form a pair by the consistency check of section
+// 5.6.2.1.4 of NIST SP 800-56A, i.e., D*G = (X,Y).
+func (priv *PrivateKey) Validate() error {
+	if nil == priv.D {
+		return errors.New("The private key is incomplete")
+	}
+	if err := priv.PublicKey.Validate(); nil != err {
+		return err
+	}
+
+	if (priv.D.Sign() <= 0) || (priv.D.Cmp(priv.Curve.Params().N) >= 0) {
+		return errors.New("The private key is out of range")
+	}
+
+	x, y := priv.Curve.ScalarBaseMult(priv.D.Bytes())
+	if (0 != x.Cmp(priv.X)) || (0 != y.Cmp(priv.Y)) {
+		return errors.New("The private key mismatches the public key")
+	}
+
+	return nil
+}
+
+// Lock moves the words of D into memory locked against swapping, which
+// suits long-lived keys. It is supported on Linux only, and is subject to
+// RLIMIT_MEMLOCK. The memory is released by Destroy. D must not be resized
+// in place afterwards.
+//
+// Copies of a locked key, such as by dereferencing, share both D and the
+// locked memory. Destroy through any of them zeroes and detaches the shared
+// D before releasing the memory once, so that the others can no longer sign
+// but never touch the unmapped memory. D must not be copied into another
+// big.Int by value, which would escape the detaching.
+func (priv *PrivateKey) Lock() error {
+	if nil != priv.locked {
+		return nil
+	}
+	if (nil == priv.D) || (0 == priv.D.Sign()) {
+		return errDestroyedKey
+	}
+
+	words := priv.D.Bits()
+	mem, err := lockMemory(len(words))
+	if nil != err {
+		return err
+	}
+
+	copy(mem.words, words)
+	wipeInt(priv.D)
+	// keep the same *big.Int, which may be shared, on top of the locked words
+	priv.D.SetBits(mem.words)
+	priv.locked = mem
+
+	return nil
+}
+
+// Destroy zeroes D and releases any locked memory backing it, after which
+// the key can no longer sign. The public key is left as it is.
+func (priv *PrivateKey) Destroy() {
+	wipeInt(priv.D)
+	priv.D = nil
+
+	if nil != priv.locked {
+		priv.locked.release()
+		priv.locked = nil
+	}
+}
+
 // Public returns the public key corresponding to priv.
 func (priv *PrivateKey) Public() crypto.PublicKey {
 	return &priv.PublicKey
 }
 
-// Sign signs digest with priv, reading randomness from rand. The opts argument
-// is not currently used but, in keeping with the crypto.Signer interface,
-// should be the hash function used to digest the message.
+// Sign signs digest with priv, reading randomness from rand. If opts is a
+// *SignerOpts, the nonce is derived as it specifies. Otherwise, the hedged
+// nonce is used, with the HMAC over opts.HashFunc() if available. The digest
+// must be as long as the output of the hash function selected by opts, if
+// any.
 //
 // This method implements crypto.Signer, which is an interface to support keys
 // where the private part is kept in, for example, a hardware module. Common
 // uses should use the Sign function in this package directly.
 func (priv *PrivateKey) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
-	r, s, err := Sign(rand, priv, digest)
+	signerOpts, ok := opts.(*SignerOpts)
+	if !ok {
+		signerOpts = new(SignerOpts)
+		if nil != opts {
+			signerOpts.Hash = opts.HashFunc()
+		}
+	}
+	if nil == signerOpts {
+		signerOpts = new(SignerOpts)
+	}
+
+	if n := signerOpts.digestSize(); (0 != n) && (len(digest) != n) {
+		return nil, errDigestLength
+	}
+
+	r, s, err := SignWithOpts(rand, priv, digest, signerOpts)
 	if nil != err {
 		return nil, err
 	}
@@ -0,0 +1,104 @@
+package ecdsa_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+)
+
+func TestNewPrivateKey(t *testing.T) {
+	curve := elliptic.P256k1()
+	N := curve.Params().N
+
+	testCases := []struct {
+		name    string
+		d       *big.Int
+		isValid bool
+	}{
+		{"one", big.NewInt(1), true},
+		{"N-1", new(big.Int).Sub(N, big.NewInt(1)), true},
+		{"zero", big.NewInt(0), false},
+		{"negative", big.NewInt(-1), false},
+		{"N", new(big.Int).Set(N), false},
+		{"nil", nil, false},
+	}
+
+	for _, c := range testCases {
+		priv, err := ecdsa.NewPrivateKey(curve, c.d)
+		if !c.isValid {
+			if nil == err {
+				t.Errorf("%s: construction should fail", c.name)
+			}
+			continue
+		}
+
+		if nil != err {
+			t.Fatalf("%s: unexpected error %v", c.name, err)
+		}
+		if err := priv.Validate(); nil != err {
+			t.Fatalf("%s: unexpected error %v", c.name, err)
+		}
+	}
+
+	if _, err := ecdsa.NewPrivateKey(nil, big.NewInt(1)); nil == err {
+		t.Error("construction without a curve should fail")
+	}
+}
+
+func TestPrivateKeyValidate(t *testing.T) {
+	curve := elliptic.P256k1()
+
+	priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if err := priv.Validate(); nil != err {
+		t.Fatal(err)
+	}
+
+	other, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name string
+		priv *ecdsa.PrivateKey
+	}{
+		{"zero", &ecdsa.PrivateKey{PublicKey: priv.PublicKey, D: big.NewInt(0)}},
+		{"D+N", &ecdsa.PrivateKey{PublicKey: priv.PublicKey,
+			D: new(big.Int).Add(priv.D, curve.Params().N)}},
+		{"mismatched", &ecdsa.PrivateKey{PublicKey: other.PublicKey, D: priv.D}},
+		{"missing D", &ecdsa.PrivateKey{PublicKey: priv.PublicKey}},
+	}
+
+	for _, c := range testCases {
+		if nil == c.priv.Validate() {
+			t.Errorf("%s: validation should fail", c.name)
+		}
+	}
+}
+
+func TestGenerateKeyWithMethod(t *testing.T) {
+	curve := elliptic.P256k1()
+
+	methods := []elliptic.KeyGenMethod{elliptic.ExtraRandomBits, elliptic.RejectionSampling}
+	for _, method := range methods {
+		priv, err := ecdsa.GenerateKeyWithMethod(curve, rand.Reader, method)
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		if err := priv.Validate(); nil != err {
+			t.Fatalf("method %d: %v", method, err)
+		}
+	}
+
+	if _, err := ecdsa.GenerateKeyWithMethod(curve, rand.Reader,
+		elliptic.KeyGenMethod(42)); nil == err {
+		t.Fatal("the unknown method should be rejected")
+	}
+}
@@ -42,6 +42,10 @@ func (pub *PublicKey) Decompress(curve elliptic.Curve, data []byte) error {
 	pub.Curve = curve
 
 	pub.X = new(big.Int).SetBytes(data[1:])
+	if pub.X.Cmp(curve.Params().P) >= 0 {
+		return errors.New("X is out of range")
+	}
+
 	var err error
 	//pub.Y, err = DecompressPoint(curve, pub.X, yOdd)
 	pub.Y, err = curve.DecompressPoint(pub.X, yOdd)
@@ -67,7 +71,7 @@ func (pub *PublicKey) UncompressedDecode(curve elliptic.Curve, data []byte) erro
 	offset += ell
 	pub.Y = new(big.Int).SetBytes(data[offset:])
 
-	return nil
+	return pub.Validate()
 }
 
 // UncompressedEncode encodes the public key into a byte sequence
@@ -104,10 +108,38 @@ func (pub *PublicKey) Parse(curve elliptic.Curve, data []byte) error {
 	}
 
 	if nil == err {
-		if !curve.IsOnCurve(pub.X, pub.Y) {
-			err = errors.New("The parsed point is off curve")
-		}
+		err = pub.Validate()
 	}
 
 	return err
 }
+
+// Validate performs the full public key validation of section 5.6.2.3.3 of
+// NIST SP 800-56A and section 3.2.2.1 of [SECG]: the point must not be the
+// point at infinity, its coordinates must be in [0,P), it must be on the
+// curve, and it must lie in the prime-order subgroup.
+func (pub *PublicKey) Validate() error {
+	if (nil == pub.Curve) || (nil == pub.X) || (nil == pub.Y) {
+		return errors.New("The public key is incomplete")
+	}
+
+	if (0 == pub.X.Sign()) && (0 == pub.Y.Sign()) {
+		return errors.New("The public key is the point at infinity")
+	}
+
+	P := pub.Curve.Params().P
+	if (pub.X.Sign() < 0) || (pub.X.Cmp(P) >= 0) ||
+		(pub.Y.Sign() < 0) || (pub.Y.Cmp(P) >= 0) {
+		return errors.New("The public key is out of range")
+	}
+
+	if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+		return errors.New("The public key is off curve")
+	}
+
+	if !elliptic.IsInSubgroup(pub.Curve, pub.X, pub.Y) {
+		return errors.New("The public key is out of the prime-order subgroup")
+	}
+
+	return nil
+}
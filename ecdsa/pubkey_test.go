@@ -3,6 +3,7 @@ package ecdsa_test
 import (
 	"bytes"
 	"crypto/rand"
+	"math/big"
 	"testing"
 
 	"github.com/sammy00/crypto/ecdsa"
@@ -128,3 +129,102 @@ func TestPubKeyUncompressedEncoding(t *testing.T) {
 
 	testPubKeyEquality(pub, pubDec, t)
 }
+
+func TestPubKeyParsingSmallSubgroup(t *testing.T) {
+	// y^2 = x^3 + 8 over GF(60037), which has 4*14887 points
+	curve := &elliptic.KoblitzCurve{
+		CurveParams: &elliptic.CurveParams{
+			P:       big.NewInt(60037),
+			N:       big.NewInt(14887),
+			B:       big.NewInt(8),
+			Gx:      big.NewInt(24235),
+			Gy:      big.NewInt(5086),
+			BitSize: 16,
+			Name:    "toy",
+			H:       big.NewInt(4),
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		x, y    *big.Int
+		isValid bool
+	}{
+		{"base point", curve.Gx, curve.Gy, true},
+		{"point of order 2", big.NewInt(60035), big.NewInt(0), false},
+		{"point of order 2N", big.NewInt(2), big.NewInt(4), false},
+	}
+
+	for _, c := range testCases {
+		pub := &ecdsa.PublicKey{Curve: curve, X: c.x, Y: c.y}
+		data, err := pub.UncompressedEncode()
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		err = new(ecdsa.PublicKey).Parse(curve, data)
+		if c.isValid && (nil != err) {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		} else if !c.isValid && (nil == err) {
+			t.Errorf("%s: parsing should fail", c.name)
+		}
+	}
+}
+
+func TestPubKeyValidate(t *testing.T) {
+	curve := elliptic.P256k1()
+	P := curve.Params().P
+
+	priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name    string
+		x, y    *big.Int
+		isValid bool
+	}{
+		{"valid", priv.X, priv.Y, true},
+		{"infinity", big.NewInt(0), big.NewInt(0), false},
+		{"X+P", new(big.Int).Add(priv.X, P), priv.Y, false},
+		{"Y+P", priv.X, new(big.Int).Add(priv.Y, P), false},
+		{"negative Y", priv.X, new(big.Int).Sub(priv.Y, P), false},
+		{"off curve", priv.X, new(big.Int).Add(priv.Y, big.NewInt(1)), false},
+		{"missing Y", priv.X, nil, false},
+	}
+
+	for _, c := range testCases {
+		pub := &ecdsa.PublicKey{Curve: curve, X: c.x, Y: c.y}
+		if err := pub.Validate(); c.isValid && (nil != err) {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		} else if !c.isValid && (nil == err) {
+			t.Errorf("%s: validation should fail", c.name)
+		}
+	}
+
+	// the point of order 2N on the toy curve is out of the subgroup
+	pub := &ecdsa.PublicKey{Curve: toyCurve(), X: big.NewInt(2), Y: big.NewInt(4)}
+	if nil == pub.Validate() {
+		t.Fatal("point of order 2N should be rejected")
+	}
+}
+
+func TestPubKeyParsingXOutOfRange(t *testing.T) {
+	curve := elliptic.P256k1()
+
+	// X = P+1 would decompress as X = 1 if not reduced, and 1^3+7 = 8 is a
+	// quadratic residue modulo P
+	data := append([]byte{0x02}, new(big.Int).Add(curve.Params().P,
+		big.NewInt(1)).Bytes()...)
+	if nil == new(ecdsa.PublicKey).Parse(curve, data) {
+		t.Fatal("X >= P should be rejected")
+	}
+
+	x, y := curve.ScalarBaseMult([]byte{1})
+	data = append([]byte{0x04}, x.Bytes()...)
+	data = append(data, new(big.Int).Add(y, curve.Params().P).Bytes()...)
+	if nil == new(ecdsa.PublicKey).Parse(curve, data) {
+		t.Fatal("Y >= P should be rejected")
+	}
+}
@@ -0,0 +1,132 @@
+package ecdsa
+
+import (
+	"crypto"
+	"errors"
+	"math/big"
+
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/misc"
+)
+
+const (
+	// compactSigMagicOffset is the offset of the header byte of compact
+	// signatures, as is used by bitcoin
+	compactSigMagicOffset byte = 27
+	// compactSigCompPubKey is the flag of the header byte telling the
+	// public key is in the compressed form
+	compactSigCompPubKey byte = 4
+)
+
+// RecoverPublicKey recovers the public key from the signature (r,s) over
+// hash and the recovery id, whose bit 0 is the parity of y of the point R
+// and bit 1 tells if the x of R is r+N rather than r, which happens with a
+// tiny probability when N < P. It follows section 4.1.6 of [SECG].
+func RecoverPublicKey(c elliptic.Curve, hash []byte, r, s *big.Int,
+	recid byte) (*PublicKey, error) {
+	params := c.Params()
+	N := params.N
+
+	if recid > 3 {
+		return nil, errors.New("invalid recovery id")
+	}
+	if (r.Sign() <= 0) || (s.Sign() <= 0) || (r.Cmp(N) >= 0) || (s.Cmp(N) >= 0) {
+		return nil, errors.New("signature values out of range")
+	}
+
+	// x = r + j*N
+	x := new(big.Int).Set(r)
+	if 0 != recid&2 {
+		x.Add(x, N)
+	}
+	if x.Cmp(params.P) >= 0 {
+		return nil, errors.New("x of R is out of the field")
+	}
+
+	y, err := c.DecompressPoint(x, 1 == recid&1)
+	if nil != err {
+		return nil, err
+	}
+
+	// Q = r^{-1}(s*R - e*G) = u1*G + u2*R
+	// with u1 = -e*r^{-1} and u2 = s*r^{-1}
+	var rInv *big.Int
+	if in, ok := c.(invertible); ok {
+		rInv = in.Inverse(r)
+	} else {
+		rInv = new(big.Int).ModInverse(r, N)
+	}
+
+	e := hashToInt(hash, c)
+	u1 := e.Mul(e, rInv)
+	u1.Neg(u1)
+	u1.Mod(u1, N)
+	u2 := new(big.Int).Mul(s, rInv)
+	u2.Mod(u2, N)
+
+	x1, y1 := c.ScalarBaseMult(u1.Bytes())
+	x2, y2 := c.ScalarMult(x, y, u2.Bytes())
+	qx, qy := c.Add(x1, y1, x2, y2)
+	if (0 == qx.Sign()) && (0 == qy.Sign()) {
+		return nil, errors.New("the recovered public key is the point at infinity")
+	}
+
+	return &PublicKey{Curve: c, X: qx, Y: qy}, nil
+}
+
+// SignCompact signs the hash with the RFC 6979 nonce over SHA-256, and
+// outputs the compact signature as is used by bitcoin, which is made of a
+// header byte followed by r and s, each as long as the field. The header
+// byte is 27 + the recovery id, plus 4 if isCompressedKey tells the public
+// key should be recovered in the compressed form.
+func SignCompact(priv *PrivateKey, hash []byte, isCompressedKey bool) ([]byte, error) {
+	r, s, err := SignDeterministic(priv, hash, crypto.SHA256)
+	if nil != err {
+		return nil, err
+	}
+
+	for recid := byte(0); recid < 4; recid++ {
+		pub, err := RecoverPublicKey(priv.Curve, hash, r, s, recid)
+		if (nil != err) || (0 != pub.X.Cmp(priv.X)) || (0 != pub.Y.Cmp(priv.Y)) {
+			continue
+		}
+
+		byteLen := (priv.Curve.Params().BitSize + 7) / 8
+		sig := make([]byte, 1+2*byteLen)
+
+		sig[0] = compactSigMagicOffset + recid
+		if isCompressedKey {
+			sig[0] += compactSigCompPubKey
+		}
+		misc.ReverseCopy(sig[1:1+byteLen], r.Bytes())
+		misc.ReverseCopy(sig[1+byteLen:], s.Bytes())
+
+		return sig, nil
+	}
+
+	return nil, errors.New("no valid recovery id for the signature")
+}
+
+// RecoverCompact recovers the public key from the compact signature output
+// by SignCompact, along with whether it should be in the compressed form
+func RecoverCompact(c elliptic.Curve, sig, hash []byte) (*PublicKey, bool, error) {
+	byteLen := (c.Params().BitSize + 7) / 8
+	if len(sig) != 1+2*byteLen {
+		return nil, false, errors.New("invalid compact signature size")
+	}
+
+	header := sig[0] - compactSigMagicOffset
+	if (sig[0] < compactSigMagicOffset) || (header > compactSigCompPubKey+3) {
+		return nil, false, errors.New("invalid compact signature header")
+	}
+
+	r := new(big.Int).SetBytes(sig[1 : 1+byteLen])
+	s := new(big.Int).SetBytes(sig[1+byteLen:])
+
+	pub, err := RecoverPublicKey(c, hash, r, s, header&3)
+	if nil != err {
+		return nil, false, err
+	}
+
+	return pub, 0 != header&compactSigCompPubKey, nil
+}
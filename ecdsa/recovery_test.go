@@ -0,0 +1,149 @@
+package ecdsa_test
+
+import (
+	stdElliptic "crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/misc"
+)
+
+// toyCurve returns y^2 = x^3 + 8 over GF(60037), whose order N of the base
+// point is much smaller than P, so that r+N is a common x of R
+func toyCurve() elliptic.Curve {
+	return &elliptic.KoblitzCurve{
+		CurveParams: &elliptic.CurveParams{
+			P:       big.NewInt(60037),
+			N:       big.NewInt(14887),
+			B:       big.NewInt(8),
+			Gx:      big.NewInt(24235),
+			Gy:      big.NewInt(5086),
+			BitSize: 16,
+			Name:    "toy",
+			H:       big.NewInt(4),
+		},
+	}
+}
+
+func TestSignCompactAndRecover(t *testing.T) {
+	curves := map[string]elliptic.Curve{
+		"secp256k1": elliptic.P256k1(),
+		"P-256":     elliptic.FromStd(stdElliptic.P256()),
+	}
+
+	for name, curve := range curves {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 16; i++ {
+				priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+				if nil != err {
+					t.Fatal(err)
+				}
+				digest := sha256.Sum256([]byte{byte(i)})
+				compressed := 0 == i%2
+
+				sig, err := ecdsa.SignCompact(priv, digest[:], compressed)
+				if nil != err {
+					t.Fatal(err)
+				}
+
+				pub, wasCompressed, err := ecdsa.RecoverCompact(curve, sig, digest[:])
+				if nil != err {
+					t.Fatal(err)
+				}
+				if (0 != pub.X.Cmp(priv.X)) || (0 != pub.Y.Cmp(priv.Y)) {
+					t.Fatal("the recovered public key should be the signer's")
+				}
+				if wasCompressed != compressed {
+					t.Fatalf("invalid compression flag: got %v, want %v", wasCompressed, compressed)
+				}
+
+				// the other parity gives another key
+				sig[0] ^= 1
+				pub, _, err = ecdsa.RecoverCompact(curve, sig, digest[:])
+				if (nil == err) && (0 == pub.X.Cmp(priv.X)) && (0 == pub.Y.Cmp(priv.Y)) {
+					t.Fatal("a wrong recovery id should give another key")
+				}
+			}
+		})
+	}
+}
+
+func TestRecoverPublicKeyOverflow(t *testing.T) {
+	curve := toyCurve()
+	N := curve.Params().N
+
+	d := big.NewInt(1234)
+	pubX, pubY := curve.ScalarBaseMult(d.Bytes())
+	digest := sha256.Sum256([]byte("testing"))
+
+	var overflows int
+	for k := int64(1); k < 256; k++ {
+		// pick out k with N <= R.x < 2N
+		Rx, Ry := curve.ScalarBaseMult(big.NewInt(k).Bytes())
+		if (Rx.Cmp(N) < 0) || (Rx.Cmp(new(big.Int).Lsh(N, 1)) >= 0) {
+			continue
+		}
+
+		r := new(big.Int).Sub(Rx, N)
+		e := new(big.Int).SetBytes(digest[:])
+		e.Rsh(e, uint(len(digest)*8-N.BitLen()))
+		s := new(big.Int).Mul(r, d)
+		s.Add(s, e)
+		s.Mul(s, new(big.Int).ModInverse(big.NewInt(k), N))
+		s.Mod(s, N)
+		if (0 == r.Sign()) || (0 == s.Sign()) {
+			continue
+		}
+
+		recid := byte(2)
+		if misc.IsOdd(Ry) {
+			recid |= 1
+		}
+
+		pub, err := ecdsa.RecoverPublicKey(curve, digest[:], r, s, recid)
+		if nil != err {
+			t.Fatal(err)
+		}
+		if (0 != pub.X.Cmp(pubX)) || (0 != pub.Y.Cmp(pubY)) {
+			t.Fatalf("k=%d: invalid public key: got (%d,%d), want (%d,%d)",
+				k, pub.X, pub.Y, pubX, pubY)
+		}
+		overflows++
+	}
+
+	if 0 == overflows {
+		t.Fatal("no overflow case is checked")
+	}
+}
+
+func TestRecoverCompactInvalid(t *testing.T) {
+	curve := elliptic.P256k1()
+	digest := sha256.Sum256([]byte("testing"))
+
+	priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	sig, err := ecdsa.SignCompact(priv, digest[:], true)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	testCases := map[string][]byte{
+		"short":      sig[:64],
+		"header 26":  append([]byte{26}, sig[1:]...),
+		"header 35":  append([]byte{35}, sig[1:]...),
+		"zero r":     append(append([]byte{sig[0]}, make([]byte, 32)...), sig[33:]...),
+		"s beyond N": append(append([]byte{}, sig[:33]...), curve.Params().N.Bytes()...),
+	}
+
+	for name, c := range testCases {
+		if _, _, err := ecdsa.RecoverCompact(curve, c, digest[:]); nil == err {
+			t.Fatalf("%s: recovery should fail", name)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package ecdsa
+
+// References:
+//   [RFC6979]: Deterministic Usage of the Digital Signature Algorithm (DSA)
+//     and Elliptic Curve Digital Signature Algorithm (ECDSA),
+//     https://tools.ietf.org/html/rfc6979
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"errors"
+	"hash"
+	"math/big"
+
+	"github.com/sammy00/crypto/elliptic"
+)
+
+// errHashUnavailable is returned if the hash function isn't linked into the
+// binary
+var errHashUnavailable = errors.New("ecdsa: the requested hash function is unavailable")
+
+// NonceRFC6979 is the HMAC-DRBG generating the nonces as is specified in
+// section 3.2 of [RFC6979], which also serves other signature schemes over
+// elliptic curves, such as SM2 and GOST R 34.10
+type NonceRFC6979 struct {
+	c    elliptic.Curve
+	k, v []byte
+	mac  func(key []byte) hash.Hash
+}
+
+// NewNonceRFC6979 instantiates the HMAC-DRBG over the hash function h with
+// the private key x and the message digest, following steps a-g of section
+// 3.2 of [RFC6979]. The optional extra data goes after the digest as is
+// specified in section 3.6, where fresh randomness makes the nonces hedged.
+// The caller should Wipe the DRBG once done, as its state derives from x.
+func NewNonceRFC6979(c elliptic.Curve, x *big.Int, digest []byte,
+	h func() hash.Hash, extra []byte) *NonceRFC6979 {
+	size := h().Size()
+	g := &NonceRFC6979{
+		c:   c,
+		k:   make([]byte, size),
+		v:   make([]byte, size),
+		mac: func(key []byte) hash.Hash { return hmac.New(h, key) },
+	}
+	for i := range g.v {
+		g.v[i] = 0x01
+	}
+
+	// int2octets(x) || bits2octets(h1) || extra
+	xOctets := int2octets(x, c)
+	seed := make([]byte, 0, 2*len(xOctets)+len(extra))
+	seed = append(seed, xOctets...)
+	seed = append(seed, bits2octets(digest, c)...)
+	seed = append(seed, extra...)
+	wipeBytes(xOctets)
+	defer wipeBytes(seed)
+
+	// K = HMAC_K(V || 0x00 || seed), V = HMAC_K(V)
+	g.update(g.k, g.k, g.v, []byte{0x00}, seed)
+	g.update(g.v, g.k, g.v)
+	// K = HMAC_K(V || 0x01 || seed), V = HMAC_K(V)
+	g.update(g.k, g.k, g.v, []byte{0x01}, seed)
+	g.update(g.v, g.k, g.v)
+
+	return g
+}
+
+// Next returns the next candidate k in [1,N-1], following step h of
+// section 3.2 of [RFC6979]
+func (g *NonceRFC6979) Next() (*big.Int, error) {
+	N := g.c.Params().N
+	rlen := (N.BitLen() + 7) / 8
+
+	for {
+		t := make([]byte, 0, rlen+len(g.v))
+		for len(t) < rlen {
+			g.update(g.v, g.k, g.v)
+			t = append(t, g.v...)
+		}
+
+		k := hashToInt(t, g.c)
+		wipeBytes(t)
+
+		// K = HMAC_K(V || 0x00), V = HMAC_K(V) for the next try, which also
+		// serves the retry in case k turns out to be unsuitable
+		g.update(g.k, g.k, g.v, []byte{0x00})
+		g.update(g.v, g.k, g.v)
+
+		if (k.Sign() > 0) && (k.Cmp(N) < 0) {
+			return k, nil
+		}
+	}
+}
+
+// update overwrites dst with HMAC_key(data...), where dst may alias key or
+// data
+func (g *NonceRFC6979) update(dst, key []byte, data ...[]byte) {
+	mac := g.mac(key)
+	for _, d := range data {
+		mac.Write(d)
+	}
+
+	out := mac.Sum(nil)
+	copy(dst, out)
+	wipeBytes(out)
+}
+
+// Wipe zeroes the state of the DRBG, which derives from the private key
+func (g *NonceRFC6979) Wipe() {
+	wipeBytes(g.k)
+	wipeBytes(g.v)
+}
+
+// int2octets encodes x into a big-endian byte sequence as long as N
+func int2octets(x *big.Int, c elliptic.Curve) []byte {
+	rlen := (c.Params().N.BitLen() + 7) / 8
+
+	out := make([]byte, rlen)
+	xBytes := x.Bytes()
+	copy(out[rlen-len(xBytes):], xBytes)
+	wipeBytes(xBytes)
+
+	return out
+}
+
+// bits2octets converts the digest into an integer reduced modulo N, encoded
+// as long as N
+func bits2octets(hash []byte, c elliptic.Curve) []byte {
+	z := hashToInt(hash, c)
+	if N := c.Params().N; z.Cmp(N) >= 0 {
+		z.Sub(z, N)
+	}
+
+	return int2octets(z, c)
+}
+
+// SignDeterministic signs a hash like Sign, but derives the nonce k from the
+// private key and the hash with the HMAC-DRBG specified by [RFC6979], where
+// h is the hash function used by both the HMAC and the digest. As a result,
+// signing the same hash twice gives the same signature, and the security of
+// the private key no longer depends on any randomness.
+func SignDeterministic(priv *PrivateKey, hash []byte, h crypto.Hash) (r, s *big.Int, err error) {
+	if !h.Available() {
+		return nil, nil, errHashUnavailable
+	}
+	if (nil == priv.D) || (0 == priv.D.Sign()) {
+		return nil, nil, errDestroyedKey
+	}
+
+	g := NewNonceRFC6979(priv.Curve, priv.D, hash, h.New, nil)
+	defer g.Wipe()
+
+	return signWithNonce(priv, hash, g.Next)
+}
@@ -0,0 +1,93 @@
+package ecdsa_test
+
+import (
+	"crypto"
+	stdElliptic "crypto/elliptic"
+	"crypto/rand"
+	_ "crypto/sha1"
+	"crypto/sha256"
+	_ "crypto/sha512"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+)
+
+func TestSignDeterministic(t *testing.T) {
+	curves := map[string]elliptic.Curve{
+		"P-224": elliptic.FromStd(stdElliptic.P224()),
+		"P-256": elliptic.FromStd(stdElliptic.P256()),
+		"P-384": elliptic.FromStd(stdElliptic.P384()),
+		"P-521": elliptic.FromStd(stdElliptic.P521()),
+	}
+
+	for i, c := range rfc6979TestVec {
+		curve := curves[c.curve]
+
+		D, _ := new(big.Int).SetString(rfc6979Keys[c.curve], 16)
+		priv := &ecdsa.PrivateKey{D: D}
+		priv.Curve = curve
+		priv.X, priv.Y = curve.ScalarBaseMult(D.Bytes())
+
+		h := c.hash.New()
+		h.Write([]byte(c.message))
+		digest := h.Sum(nil)
+
+		r, s, err := ecdsa.SignDeterministic(priv, digest, c.hash)
+		if nil != err {
+			t.Fatalf("#%d unexpected error: %v", i, err)
+		}
+
+		width := (curve.Params().N.BitLen() + 3) / 4
+		if got := fmt.Sprintf("%0*x", width, r); got != c.r {
+			t.Fatalf("#%d invalid r: got %s, want %s", i, got, c.r)
+		}
+		if got := fmt.Sprintf("%0*x", width, s); got != c.s {
+			t.Fatalf("#%d invalid s: got %s, want %s", i, got, c.s)
+		}
+
+		if !ecdsa.Verify(&priv.PublicKey, digest, r, s) {
+			t.Fatalf("#%d the signature should be valid", i)
+		}
+	}
+}
+
+func TestNonceRFC6979(t *testing.T) {
+	// the nonces k of P-256 with SHA-256 in appendix A.2.5 of RFC 6979
+	testCases := []struct{ message, k string }{
+		{"sample", "a6e3c57dd01abe90086538398355dd4c3b17aa873382b0f24d6129493d8aad60"},
+		{"test", "d16b6ae827f17175e040871a1c7ec3500192c4c92677336ec2537acaee0008e0"},
+	}
+
+	curve := elliptic.FromStd(stdElliptic.P256())
+	D, _ := new(big.Int).SetString(rfc6979Keys["P-256"], 16)
+
+	for _, c := range testCases {
+		digest := sha256.Sum256([]byte(c.message))
+
+		g := ecdsa.NewNonceRFC6979(curve, D, digest[:], sha256.New, nil)
+		k, err := g.Next()
+		g.Wipe()
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		if got := fmt.Sprintf("%064x", k); got != c.k {
+			t.Fatalf("%s: invalid k: got %s, want %s", c.message, got, c.k)
+		}
+	}
+}
+
+func TestSignDeterministicUnavailableHash(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256k1(), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	// MD4 isn't linked into the test binary
+	if _, _, err := ecdsa.SignDeterministic(priv, []byte("testing"), crypto.MD4); nil == err {
+		t.Fatal("signing with an unavailable hash should fail")
+	}
+}
@@ -1,8 +1,11 @@
 package secp256k1_test
 
 import (
+	"bytes"
+	"crypto"
 	"crypto/rand"
 	"crypto/sha256"
+	"encoding/hex"
 	"testing"
 
 	"github.com/btcsuite/btcd/btcec"
@@ -26,6 +29,13 @@ func publicKeyFromLocal2BTC(pub *ecdsa.PublicKey) *btcec.PublicKey {
 	}
 }
 
+func privateKeyFromBTC2Local(priv *btcec.PrivateKey) *ecdsa.PrivateKey {
+	return &ecdsa.PrivateKey{
+		PublicKey: *publicKeyFromBTC2Local(priv.PubKey()),
+		D:         priv.D,
+	}
+}
+
 func TestBTCSecp256k1(t *testing.T) {
 	priv, err := btcec.NewPrivateKey(btcec.S256())
 	if nil != err {
@@ -112,3 +122,143 @@ func TestSecp256k1LocalAgainstBTC2(t *testing.T) {
 		}
 	}
 }
+
+// TestSignDeterministicAgainstBTC checks the RFC6979 signatures against the
+// ones of btcec, both of which output the low s
+func TestSignDeterministicAgainstBTC(t *testing.T) {
+	// vectors matching Trezor and CoreBitcoin, taken from btcec
+	testCases := []struct {
+		key, msg, signature string
+	}{
+		{
+			"cca9fbcc1b41e5a95d369eaa6ddcff73b61a4efaa279cfc6567e8daa39cbaf50",
+			"sample",
+			"3045022100af340daf02cc15c8d5d08d7735dfe6b98a474ed373bdb5fbecf7571be52b384202205009fb27f37034a9b24b707b7c6b79ca23ddef9e25f7282e8a797efe53a8f124",
+		},
+		{
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"Satoshi Nakamoto",
+			"3045022100934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d802202442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5",
+		},
+		{
+			"fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140",
+			"Satoshi Nakamoto",
+			"3045022100fd567d121db66e382991534ada77a6bd3106f0a1098c231e47993447cd6af2d002206b39cd0eb1bc8603e159ef5c20a5c8ad685a45b06ce9bebed3f153d10d93bed5",
+		},
+		{
+			"f8b8af8ce3c7cca5e300d33939540c10d45ce001b8f252bfbc57ba0342904181",
+			"Alan Turing",
+			"304402207063ae83e7f62bbb171798131b4a0564b956930092b33b07b395615d9ec7e15c022058dfcc1e00a35e1572f366ffe34ba0fc47db1e7189759b9fb233c5b05ab388ea",
+		},
+		{
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"All those moments will be lost in time, like tears in rain. Time to die...",
+			"30450221008600dbd41e348fe5c9465ab92d23e3db8b98b873beecd930736488696438cb6b0220547fe64427496db33bf66019dacbf0039c04199abb0122918601db38a72cfc21",
+		},
+		{
+			"e91671c46231f833a6406ccbea0e3e392c76c167bac1cb013f6f1013980455c2",
+			"There is a computer disease that anybody who works with computers knows about. It's a very serious disease and it interferes completely with the work. The trouble with computers is that you 'play' with them!",
+			"3045022100b552edd27580141f3b2a5463048cb7cd3e047b97c9f98076c32dbdf85a68718b0220279fa72dd19bfae05577e06c7c0c1900c371fcd5893f7e1d56a37d30174671f6",
+		},
+	}
+
+	for i, c := range testCases {
+		key, _ := hex.DecodeString(c.key)
+		privBTC, _ := btcec.PrivKeyFromBytes(btcec.S256(), key)
+		priv := privateKeyFromBTC2Local(privBTC)
+
+		digest := sha256.Sum256([]byte(c.msg))
+		r, s, err := ecdsa.SignDeterministic(priv, digest[:], crypto.SHA256)
+		if nil != err {
+			t.Fatalf("#%d unexpected error: %v", i, err)
+		}
+
+		der, _ := hex.DecodeString(c.signature)
+		sig, err := btcec.ParseDERSignature(der, btcec.S256())
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		if 0 != r.Cmp(sig.R) {
+			t.Fatalf("#%d invalid r: got %x, want %x", i, r, sig.R)
+		}
+		if 0 != s.Cmp(sig.S) {
+			t.Fatalf("#%d invalid s: got %x, want %x", i, s, sig.S)
+		}
+	}
+
+	// random keys should also agree with btcec
+	for i := 0; i < 64; i++ {
+		privBTC, err := btcec.NewPrivateKey(btcec.S256())
+		if nil != err {
+			t.Fatal(err)
+		}
+		priv := privateKeyFromBTC2Local(privBTC)
+
+		digest := sha256.Sum256([]byte{byte(i)})
+		r, s, err := ecdsa.SignDeterministic(priv, digest[:], crypto.SHA256)
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		sig, err := privBTC.Sign(digest[:])
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		if 0 != r.Cmp(sig.R) {
+			t.Fatalf("invalid r: got %x, want %x", r, sig.R)
+		}
+		if 0 != s.Cmp(sig.S) {
+			t.Fatalf("invalid s: got %x, want %x", s, sig.S)
+		}
+	}
+}
+
+// TestSignCompactAgainstBTC checks the compact signatures and the public key
+// recovery against the ones of btcec
+func TestSignCompactAgainstBTC(t *testing.T) {
+	curve := elliptic.P256k1()
+
+	for i := 0; i < 64; i++ {
+		privBTC, err := btcec.NewPrivateKey(btcec.S256())
+		if nil != err {
+			t.Fatal(err)
+		}
+		priv := privateKeyFromBTC2Local(privBTC)
+
+		digest := sha256.Sum256([]byte{byte(i)})
+		compressed := 0 == i%2
+
+		sig, err := ecdsa.SignCompact(priv, digest[:], compressed)
+		if nil != err {
+			t.Fatal(err)
+		}
+		sigBTC, err := btcec.SignCompact(btcec.S256(), privBTC, digest[:], compressed)
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		// both use the RFC 6979 nonce and the low s
+		if !bytes.Equal(sig, sigBTC) {
+			t.Fatalf("invalid compact signature: got %x, want %x", sig, sigBTC)
+		}
+
+		pubBTC, wasCompressed, err := btcec.RecoverCompact(btcec.S256(), sig, digest[:])
+		if nil != err {
+			t.Fatal(err)
+		}
+		pub, wasCompressedLocal, err := ecdsa.RecoverCompact(curve, sigBTC, digest[:])
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		if (0 != pub.X.Cmp(pubBTC.X)) || (0 != pub.Y.Cmp(pubBTC.Y)) {
+			t.Fatalf("invalid public key: got (%x,%x), want (%x,%x)",
+				pub.X, pub.Y, pubBTC.X, pubBTC.Y)
+		}
+		if (wasCompressed != compressed) || (wasCompressedLocal != compressed) {
+			t.Fatal("the compression flag should be kept")
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package ecdsa
+
+import "math/big"
+
+// secretBuffer tracks the big integers and byte slices holding secrets,
+// such as the nonce k and its inverse, so that all of them can be zeroed
+// once the computation is done. Since math/big may reallocate the words of
+// an integer as it grows, this is a best effort only, which keeps the final
+// buffers from lingering in the heap.
+type secretBuffer struct {
+	ints  []*big.Int
+	bytes [][]byte
+}
+
+// track registers x for wiping and returns it
+func (buf *secretBuffer) track(x *big.Int) *big.Int {
+	buf.ints = append(buf.ints, x)
+	return x
+}
+
+// trackBytes registers b for wiping and returns it
+func (buf *secretBuffer) trackBytes(b []byte) []byte {
+	buf.bytes = append(buf.bytes, b)
+	return b
+}
+
+// wipe zeroes everything tracked so far
+func (buf *secretBuffer) wipe() {
+	for _, x := range buf.ints {
+		wipeInt(x)
+	}
+	for _, b := range buf.bytes {
+		wipeBytes(b)
+	}
+
+	buf.ints, buf.bytes = nil, nil
+}
+
+// wipeInt zeroes all the words backing x, including the spare capacity, and
+// sets x to 0 without any backing words, which may be about to be unmapped
+func wipeInt(x *big.Int) {
+	if nil == x {
+		return
+	}
+
+	words := x.Bits()
+	words = words[:cap(words)]
+	for i := range words {
+		words[i] = 0
+	}
+
+	x.SetBits(nil)
+}
+
+// wipeBytes zeroes b
+func wipeBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
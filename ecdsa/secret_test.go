@@ -0,0 +1,123 @@
+package ecdsa_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"runtime"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+)
+
+func TestPrivateKeyDestroy(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256k1(), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	D := priv.D
+	digest := sha256.Sum256([]byte("testing"))
+
+	priv.Destroy()
+
+	if nil != priv.D {
+		t.Fatal("D should be dropped")
+	}
+	if 0 != D.Sign() {
+		t.Fatal("the shared D should be zeroed")
+	}
+	if (nil == priv.X) || (nil == priv.Y) {
+		t.Fatal("the public key should be kept")
+	}
+
+	if _, _, err := ecdsa.Sign(rand.Reader, priv, digest[:]); nil == err {
+		t.Fatal("signing with the destroyed key should fail")
+	}
+	if _, _, err := ecdsa.SignDeterministic(priv, digest[:], crypto.SHA256); nil == err {
+		t.Fatal("signing with the destroyed key should fail")
+	}
+
+	// destroying twice is harmless
+	priv.Destroy()
+}
+
+func TestPrivateKeyLock(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256k1(), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	want := priv.D.String()
+	digest := sha256.Sum256([]byte("testing"))
+
+	r1, s1, err := ecdsa.SignDeterministic(priv, digest[:], crypto.SHA256)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	if err := priv.Lock(); nil != err {
+		if "linux" == runtime.GOOS {
+			// mlock may be forbidden by RLIMIT_MEMLOCK in some sandboxes
+			t.Skipf("mlock unavailable: %v", err)
+		}
+		return
+	}
+	if got := priv.D.String(); got != want {
+		t.Fatalf("invalid D after locking: got %s, want %s", got, want)
+	}
+	if err := priv.Lock(); nil != err {
+		t.Fatalf("locking twice should be harmless: %v", err)
+	}
+
+	// signatures stay the same
+	r2, s2, err := ecdsa.SignDeterministic(priv, digest[:], crypto.SHA256)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if (0 != r1.Cmp(r2)) || (0 != s1.Cmp(s2)) {
+		t.Fatal("locking should not change the signature")
+	}
+	if err := priv.Validate(); nil != err {
+		t.Fatal(err)
+	}
+
+	D := priv.D
+	priv.Destroy()
+
+	// the shared D is detached from the unmapped memory
+	if 0 != D.Sign() {
+		t.Fatal("the shared D should be zeroed")
+	}
+	D.SetInt64(42)
+}
+
+func TestPrivateKeyCopyDestroy(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256k1(), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("testing"))
+
+	if err := priv.Lock(); nil != err {
+		if "linux" == runtime.GOOS {
+			t.Skipf("mlock unavailable: %v", err)
+		}
+		return
+	}
+
+	clone := *priv
+	priv.Destroy()
+
+	// the copy shares D, which is zeroed and detached from the memory
+	if (nil == clone.D) || (0 != clone.D.Sign()) {
+		t.Fatal("the D shared by the copy should be zeroed")
+	}
+	if _, _, err := ecdsa.Sign(rand.Reader, &clone, digest[:]); nil == err {
+		t.Fatal("signing with the copy of the destroyed key should fail")
+	}
+	clone.D.SetInt64(42)
+
+	// releasing the shared memory twice is harmless
+	clone.Destroy()
+	priv.Destroy()
+}
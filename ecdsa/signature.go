@@ -0,0 +1,149 @@
+package ecdsa
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/misc"
+)
+
+// Compact64Len is the length in bytes of a signature in the 64-byte compact
+// form, as is used by libsecp256k1 for curves of 256 bits
+const Compact64Len = 64
+
+// Signature is an ECDSA signature (r,s), which can be encoded into the ASN.1
+// DER form, the fixed-width r||s form of IEEE P1363 and the 64-byte compact
+// form. The DER form serves its encoding.BinaryMarshaler, and the hex of the
+// DER form serves its encoding.TextMarshaler.
+type Signature struct {
+	R, S *big.Int
+}
+
+// ParseRawSignature decodes the signature in the r||s form of IEEE P1363,
+// where both r and s are as long as the order N of the curve, as is used by
+// JOSE, COSE and WebAuthn
+func ParseRawSignature(c elliptic.Curve, data []byte) (*Signature, error) {
+	byteLen := (c.Params().N.BitLen() + 7) / 8
+	if len(data) != 2*byteLen {
+		return nil, errors.New("ecdsa: invalid raw signature length")
+	}
+
+	return &Signature{
+		R: new(big.Int).SetBytes(data[:byteLen]),
+		S: new(big.Int).SetBytes(data[byteLen:]),
+	}, nil
+}
+
+// ParseCompact64Signature decodes the signature in the 64-byte compact form,
+// which is r||s with 32 bytes each
+func ParseCompact64Signature(data []byte) (*Signature, error) {
+	if Compact64Len != len(data) {
+		return nil, errors.New("ecdsa: invalid compact signature length")
+	}
+
+	return &Signature{
+		R: new(big.Int).SetBytes(data[:Compact64Len/2]),
+		S: new(big.Int).SetBytes(data[Compact64Len/2:]),
+	}, nil
+}
+
+// Raw encodes the signature into the r||s form of IEEE P1363, where both r
+// and s are padded to the length of the order N of the curve
+func (sig *Signature) Raw(c elliptic.Curve) ([]byte, error) {
+	byteLen := (c.Params().N.BitLen() + 7) / 8
+	return sig.fixedWidth(byteLen)
+}
+
+// Compact64 encodes the signature into the 64-byte compact form
+func (sig *Signature) Compact64() ([]byte, error) {
+	return sig.fixedWidth(Compact64Len / 2)
+}
+
+// DER encodes the signature into the ASN.1 DER form
+func (sig *Signature) DER() ([]byte, error) {
+	return MarshalDERSignature(sig.R, sig.S)
+}
+
+// Verify verifies the signature of hash using the public key, pub
+func (sig *Signature) Verify(pub *PublicKey, hash []byte) bool {
+	return Verify(pub, hash, sig.R, sig.S)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with the DER form
+func (sig *Signature) MarshalBinary() ([]byte, error) {
+	return sig.DER()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler with the strict DER
+// form as ParseDERSignature does
+func (sig *Signature) UnmarshalBinary(data []byte) error {
+	r, s, err := ParseDERSignature(data)
+	if nil != err {
+		return err
+	}
+
+	sig.R, sig.S = r, s
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler with the hex of the DER form
+func (sig *Signature) MarshalText() ([]byte, error) {
+	der, err := sig.DER()
+	if nil != err {
+		return nil, err
+	}
+
+	text := make([]byte, hex.EncodedLen(len(der)))
+	hex.Encode(text, der)
+
+	return text, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler with the hex of the
+// strict DER form
+func (sig *Signature) UnmarshalText(text []byte) error {
+	der := make([]byte, hex.DecodedLen(len(text)))
+	if _, err := hex.Decode(der, text); nil != err {
+		return err
+	}
+
+	return sig.UnmarshalBinary(der)
+}
+
+// fixedWidth encodes the signature into r||s with byteLen bytes each
+func (sig *Signature) fixedWidth(byteLen int) ([]byte, error) {
+	if (sig.R.Sign() < 0) || (sig.S.Sign() < 0) ||
+		(sig.R.BitLen() > 8*byteLen) || (sig.S.BitLen() > 8*byteLen) {
+		return nil, errors.New("ecdsa: signature values out of range")
+	}
+
+	out := make([]byte, 2*byteLen)
+	misc.ReverseCopy(out[:byteLen], sig.R.Bytes())
+	misc.ReverseCopy(out[byteLen:], sig.S.Bytes())
+
+	return out, nil
+}
+
+// DERToRaw converts the signature in the strict DER form into the r||s form
+// of IEEE P1363 for the curve
+func DERToRaw(c elliptic.Curve, der []byte) ([]byte, error) {
+	r, s, err := ParseDERSignature(der)
+	if nil != err {
+		return nil, err
+	}
+
+	return (&Signature{R: r, S: s}).Raw(c)
+}
+
+// RawToDER converts the signature in the r||s form of IEEE P1363 for the
+// curve into the DER form
+func RawToDER(c elliptic.Curve, raw []byte) ([]byte, error) {
+	sig, err := ParseRawSignature(c, raw)
+	if nil != err {
+		return nil, err
+	}
+
+	return sig.DER()
+}
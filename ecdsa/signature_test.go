@@ -0,0 +1,157 @@
+package ecdsa_test
+
+import (
+	"bytes"
+	"crypto"
+	stdECDSA "crypto/ecdsa"
+	stdElliptic "crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding"
+	"math/big"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/misc"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*ecdsa.Signature)(nil)
+	_ encoding.BinaryUnmarshaler = (*ecdsa.Signature)(nil)
+	_ encoding.TextMarshaler     = (*ecdsa.Signature)(nil)
+	_ encoding.TextUnmarshaler   = (*ecdsa.Signature)(nil)
+)
+
+func TestSignatureConversions(t *testing.T) {
+	curves := map[string]elliptic.Curve{
+		"secp256k1": elliptic.P256k1(),
+		"P-384":     elliptic.FromStd(stdElliptic.P384()),
+		"P-521":     elliptic.FromStd(stdElliptic.P521()),
+	}
+
+	for name, curve := range curves {
+		t.Run(name, func(t *testing.T) {
+			priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+			if nil != err {
+				t.Fatal(err)
+			}
+
+			for i := 0; i < 32; i++ {
+				digest := sha256.Sum256([]byte{byte(i)})
+				der, err := priv.Sign(rand.Reader, digest[:], crypto.SHA256)
+				if nil != err {
+					t.Fatal(err)
+				}
+
+				raw, err := ecdsa.DERToRaw(curve, der)
+				if nil != err {
+					t.Fatal(err)
+				}
+				if byteLen := (curve.Params().N.BitLen() + 7) / 8; 2*byteLen != len(raw) {
+					t.Fatalf("invalid raw length: got %d, want %d", len(raw), 2*byteLen)
+				}
+
+				sig, err := ecdsa.ParseRawSignature(curve, raw)
+				if nil != err {
+					t.Fatal(err)
+				}
+				if !sig.Verify(&priv.PublicKey, digest[:]) {
+					t.Fatal("the raw signature should be valid")
+				}
+
+				if back, err := ecdsa.RawToDER(curve, raw); nil != err {
+					t.Fatal(err)
+				} else if !bytes.Equal(back, der) {
+					t.Fatalf("invalid DER: got %x, want %x", back, der)
+				}
+
+				// binary and text round trips
+				var fromBinary, fromText ecdsa.Signature
+				data, _ := sig.MarshalBinary()
+				if err := fromBinary.UnmarshalBinary(data); nil != err {
+					t.Fatal(err)
+				}
+				text, _ := sig.MarshalText()
+				if err := fromText.UnmarshalText(text); nil != err {
+					t.Fatal(err)
+				}
+				for _, got := range []ecdsa.Signature{fromBinary, fromText} {
+					if (0 != got.R.Cmp(sig.R)) || (0 != got.S.Cmp(sig.S)) {
+						t.Fatal("the round trip should be lossless")
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSignatureRawAgainstStd(t *testing.T) {
+	// the raw form of P-256 is the one of WebAuthn and JOSE, where r and s
+	// are padded to 32 bytes each
+	std := stdElliptic.P256()
+	curve := elliptic.FromStd(std)
+
+	stdPriv, err := stdECDSA.GenerateKey(std, rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	pub := &ecdsa.PublicKey{Curve: curve, X: stdPriv.X, Y: stdPriv.Y}
+
+	digest := sha256.Sum256([]byte("testing"))
+	r, s, err := stdECDSA.Sign(rand.Reader, stdPriv, digest[:])
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	raw := make([]byte, 64)
+	misc.ReverseCopy(raw[:32], r.Bytes())
+	misc.ReverseCopy(raw[32:], s.Bytes())
+
+	sig, err := ecdsa.ParseRawSignature(curve, raw)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if !sig.Verify(pub, digest[:]) {
+		t.Fatal("the raw signature by crypto/ecdsa should be valid")
+	}
+
+	compact, err := sig.Compact64()
+	if nil != err {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(compact, raw) {
+		t.Fatalf("the compact form should be the raw form for P-256: got %x, want %x", compact, raw)
+	}
+
+	if sig, err = ecdsa.ParseCompact64Signature(compact); nil != err {
+		t.Fatal(err)
+	}
+	if !sig.Verify(pub, digest[:]) {
+		t.Fatal("the compact signature should be valid")
+	}
+}
+
+func TestSignatureInvalid(t *testing.T) {
+	curve := elliptic.P256k1()
+
+	if _, err := ecdsa.ParseRawSignature(curve, make([]byte, 63)); nil == err {
+		t.Fatal("raw signatures of invalid length should be rejected")
+	}
+	if _, err := ecdsa.ParseCompact64Signature(make([]byte, 65)); nil == err {
+		t.Fatal("compact signatures of invalid length should be rejected")
+	}
+
+	tooLong := &ecdsa.Signature{R: new(big.Int).Lsh(big.NewInt(1), 256), S: big.NewInt(1)}
+	if _, err := tooLong.Raw(curve); nil == err {
+		t.Fatal("values beyond the width should be rejected")
+	}
+	if _, err := tooLong.Compact64(); nil == err {
+		t.Fatal("values beyond the width should be rejected")
+	}
+
+	var sig ecdsa.Signature
+	if err := sig.UnmarshalText([]byte("zz")); nil == err {
+		t.Fatal("invalid hex should be rejected")
+	}
+}
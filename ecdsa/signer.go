@@ -0,0 +1,84 @@
+package ecdsa
+
+import (
+	"crypto"
+	_ "crypto/sha256" // the default hash of the nonce derivation
+	"io"
+	"math/big"
+)
+
+// NonceMode specifies how the nonce k of a signature is derived
+type NonceMode int
+
+const (
+	// NonceHedged derives k with the HMAC-DRBG of RFC 6979 fed with fresh
+	// randomness as the additional data of section 3.6, so that it stays
+	// safe if the randomness fails, and still resists the fault attacks on
+	// purely deterministic signatures
+	NonceHedged NonceMode = iota
+	// NonceRandom draws k from the randomness only, as Sign does
+	NonceRandom
+	// NonceDeterministic derives k from the private key and the digest only,
+	// as SignDeterministic does
+	NonceDeterministic
+)
+
+// SignerOpts specifies the options for SignWithOpts, which also implements
+// crypto.SignerOpts
+type SignerOpts struct {
+	// Hash is the hash function producing the digest, which also serves the
+	// HMAC of the nonce derivation. SHA-256 is used for the HMAC if Hash is
+	// left zero.
+	Hash crypto.Hash
+	// Keccak selects the legacy Keccak-256 adopted by Ethereum instead of
+	// Hash, which must be left zero then. It differs from SHA3-256 in the
+	// padding only, and has no slot among the crypto.Hash values. The HMAC is
+	// over SHA-256 for it, as libsecp256k1 does.
+	Keccak bool
+	// Nonce is how to derive the nonce, defaulting to NonceHedged
+	Nonce NonceMode
+}
+
+// HashFunc returns opts.Hash
+func (opts *SignerOpts) HashFunc() crypto.Hash {
+	return opts.Hash
+}
+
+// SignWithOpts signs a hash as Sign does, with the nonce derived in the mode
+// specified by opts, where a nil opts means the hedged mode. The rand is
+// unused in the deterministic mode.
+func SignWithOpts(rand io.Reader, priv *PrivateKey, hash []byte,
+	opts *SignerOpts) (r, s *big.Int, err error) {
+	if nil == opts {
+		opts = new(SignerOpts)
+	}
+
+	h := opts.Hash
+	if 0 == h {
+		h = crypto.SHA256
+	}
+
+	switch opts.Nonce {
+	case NonceRandom:
+		return Sign(rand, priv, hash)
+	case NonceDeterministic:
+		return SignDeterministic(priv, hash, h)
+	}
+
+	if !h.Available() {
+		return nil, nil, errHashUnavailable
+	}
+	if (nil == priv.D) || (0 == priv.D.Sign()) {
+		return nil, nil, errDestroyedKey
+	}
+
+	extra := make([]byte, h.Size())
+	if _, err := io.ReadFull(rand, extra); nil != err {
+		return nil, nil, err
+	}
+
+	g := NewNonceRFC6979(priv.Curve, priv.D, hash, h.New, extra)
+	defer g.Wipe()
+
+	return signWithNonce(priv, hash, g.Next)
+}
@@ -0,0 +1,161 @@
+package ecdsa_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"io"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+)
+
+// zeroReader mimics a broken RNG outputting nothing but zeros
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+
+	return len(p), nil
+}
+
+func TestSignWithOpts(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256k1(), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("testing"))
+
+	sign := func(rand io.Reader, opts *ecdsa.SignerOpts) (r, s string) {
+		rr, ss, err := ecdsa.SignWithOpts(rand, priv, digest[:], opts)
+		if nil != err {
+			t.Fatal(err)
+		}
+		if !ecdsa.Verify(&priv.PublicKey, digest[:], rr, ss) {
+			t.Fatal("the signature should be valid")
+		}
+
+		return rr.String(), ss.String()
+	}
+
+	t.Run("hedged", func(t *testing.T) {
+		opts := &ecdsa.SignerOpts{Hash: crypto.SHA256, Nonce: ecdsa.NonceHedged}
+
+		// fresh randomness gives fresh signatures
+		r1, _ := sign(rand.Reader, opts)
+		r2, _ := sign(rand.Reader, opts)
+		if r1 == r2 {
+			t.Fatal("hedged signatures should differ with a working RNG")
+		}
+
+		// a broken RNG still gives a secure deterministic signature, which
+		// differs from the purely deterministic one
+		r1, s1 := sign(zeroReader{}, opts)
+		r2, s2 := sign(zeroReader{}, opts)
+		if (r1 != r2) || (s1 != s2) {
+			t.Fatal("hedged signatures should be deterministic with a constant RNG")
+		}
+
+		r3, _ := sign(nil, &ecdsa.SignerOpts{Hash: crypto.SHA256, Nonce: ecdsa.NonceDeterministic})
+		if r1 == r3 {
+			t.Fatal("hedged signatures should differ from the deterministic ones")
+		}
+
+		// nil opts means the hedged mode over SHA-256
+		r4, s4 := sign(zeroReader{}, nil)
+		if (r1 != r4) || (s1 != s4) {
+			t.Fatal("nil opts should mean the hedged mode over SHA-256")
+		}
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		r1, s1 := sign(nil, &ecdsa.SignerOpts{Hash: crypto.SHA256, Nonce: ecdsa.NonceDeterministic})
+
+		r2, s2, err := ecdsa.SignDeterministic(priv, digest[:], crypto.SHA256)
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		if (r1 != r2.String()) || (s1 != s2.String()) {
+			t.Fatal("the deterministic mode should agree with SignDeterministic")
+		}
+	})
+
+	t.Run("random", func(t *testing.T) {
+		r1, _ := sign(rand.Reader, &ecdsa.SignerOpts{Nonce: ecdsa.NonceRandom})
+		r2, _ := sign(rand.Reader, &ecdsa.SignerOpts{Nonce: ecdsa.NonceRandom})
+		if r1 == r2 {
+			t.Fatal("random signatures should differ")
+		}
+	})
+}
+
+func TestPrivateKeySignHedged(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256k1(), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("testing"))
+
+	sig1, err := priv.Sign(zeroReader{}, digest[:], crypto.SHA256)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	// the hedged mode is the default
+	r, s, err := ecdsa.SignWithOpts(zeroReader{}, priv, digest[:],
+		&ecdsa.SignerOpts{Hash: crypto.SHA256, Nonce: ecdsa.NonceHedged})
+	if nil != err {
+		t.Fatal(err)
+	}
+	sig2, err := asn1.Marshal(ecdsaSig{r, s})
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(sig1, sig2) {
+		t.Fatal("PrivateKey.Sign should use the hedged nonce by default")
+	}
+
+	// *SignerOpts is honoured
+	sig3, err := priv.Sign(nil, digest[:],
+		&ecdsa.SignerOpts{Hash: crypto.SHA256, Nonce: ecdsa.NonceDeterministic})
+	if nil != err {
+		t.Fatal(err)
+	}
+	if bytes.Equal(sig1, sig3) {
+		t.Fatal("the deterministic signature should differ from the hedged one")
+	}
+}
+
+func TestPrivateKeySignNilSignerOpts(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256k1(), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("testing"))
+
+	// a typed nil *SignerOpts works as the zero SignerOpts
+	sig1, err := priv.Sign(zeroReader{}, digest[:], (*ecdsa.SignerOpts)(nil))
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	r, s, err := ecdsa.SignWithOpts(zeroReader{}, priv, digest[:], new(ecdsa.SignerOpts))
+	if nil != err {
+		t.Fatal(err)
+	}
+	sig2, err := asn1.Marshal(ecdsaSig{r, s})
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(sig1, sig2) {
+		t.Fatal("a nil *SignerOpts should behave as the zero SignerOpts")
+	}
+}
@@ -0,0 +1,114 @@
+package elliptic
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/sammy00/crypto/misc"
+)
+
+// stdCurve adapts a Curve of this package to the crypto/elliptic.Curve of
+// the standard library
+type stdCurve struct {
+	Curve
+	params *elliptic.CurveParams
+}
+
+// localCurve adapts a crypto/elliptic.Curve of the standard library to the
+// Curve of this package
+type localCurve struct {
+	elliptic.Curve
+	params *CurveParams
+}
+
+// ToStd exposes the given curve as a crypto/elliptic.Curve, so that it can
+// be passed to the standard library and third-party packages. All the
+// arithmetic is still delegated to the given curve. Note that the returned
+// parameters serve as a plain container only: calling the methods of
+// crypto/elliptic.CurveParams directly would assume a = -3, which doesn't hold
+// for curves such as secp256k1.
+func ToStd(curve Curve) elliptic.Curve {
+	if c, ok := curve.(*localCurve); ok {
+		return c.Curve
+	}
+
+	params := curve.Params()
+	return &stdCurve{
+		Curve: curve,
+		params: &elliptic.CurveParams{
+			P:       params.P,
+			N:       params.N,
+			B:       params.B,
+			Gx:      params.Gx,
+			Gy:      params.Gy,
+			BitSize: params.BitSize,
+			Name:    params.Name,
+		},
+	}
+}
+
+// FromStd turns a crypto/elliptic.Curve of the standard library, such as
+// elliptic.P256(), into a Curve of this package. The missing DecompressPoint
+// assumes the curve equation y^2 = x^3 - 3x + b used by the standard
+// library, and the cofactor is 1 as is for all the NIST curves.
+func FromStd(curve elliptic.Curve) Curve {
+	if c, ok := curve.(*stdCurve); ok {
+		return c.Curve
+	}
+
+	params := curve.Params()
+	return &localCurve{
+		Curve: curve,
+		params: &CurveParams{
+			P:       params.P,
+			N:       params.N,
+			B:       params.B,
+			Gx:      params.Gx,
+			Gy:      params.Gy,
+			BitSize: params.BitSize,
+			Name:    params.Name,
+			H:       new(big.Int).SetInt64(1),
+		},
+	}
+}
+
+// Params returns the parameters of the adapted curve
+func (curve *stdCurve) Params() *elliptic.CurveParams {
+	return curve.params
+}
+
+// Params returns the parameters of the adapted curve
+func (curve *localCurve) Params() *CurveParams {
+	return curve.params
+}
+
+// DecompressPoint estimates the Y coordinate for the given X coordinate
+func (curve *localCurve) DecompressPoint(x *big.Int, yOdd bool) (*big.Int, error) {
+	params := curve.Params()
+
+	// Y = +-sqrt(x^3-3x+b)
+	x3 := new(big.Int).Mul(x, x)
+	x3.Mul(x3, x)
+
+	threeX := new(big.Int).Lsh(x, 1)
+	threeX.Add(threeX, x)
+
+	x3.Sub(x3, threeX)
+	x3.Add(x3, params.B)
+	x3.Mod(x3, params.P) // normalize x3
+
+	y := new(big.Int).ModSqrt(x3, params.P)
+	if nil == y {
+		return nil, errors.New("x is not on the curve")
+	}
+
+	if misc.IsOdd(y) != yOdd {
+		y.Sub(params.P, y)
+	}
+	if misc.IsOdd(y) != yOdd {
+		return nil, errors.New("oddness of y is wrong")
+	}
+
+	return y, nil
+}
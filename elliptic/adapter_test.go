@@ -0,0 +1,111 @@
+package elliptic_test
+
+import (
+	stdECDSA "crypto/ecdsa"
+	stdElliptic "crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+)
+
+func TestFromStd(t *testing.T) {
+	curves := map[string]stdElliptic.Curve{
+		"P-224": stdElliptic.P224(),
+		"P-256": stdElliptic.P256(),
+		"P-384": stdElliptic.P384(),
+		"P-521": stdElliptic.P521(),
+	}
+
+	for name, std := range curves {
+		t.Run(name, func(t *testing.T) {
+			curve := elliptic.FromStd(std)
+
+			if curve.Params().Name != std.Params().Name {
+				t.Fatalf("invalid name: got %s, want %s", curve.Params().Name, std.Params().Name)
+			}
+
+			for i := 0; i < 8; i++ {
+				_, x, y, err := elliptic.GenerateKey(curve, rand.Reader)
+				if nil != err {
+					t.Fatal(err)
+				}
+
+				if !std.IsOnCurve(x, y) {
+					t.Fatal("the generated point should be on curve")
+				}
+
+				// DecompressPoint should recover the point checked by the
+				// standard library
+				data := elliptic.MarshalCompressed(curve, x, y)
+				xRec, yRec := elliptic.UnmarshalCompressed(curve, data)
+				if (nil == xRec) || (0 != xRec.Cmp(x)) || (0 != yRec.Cmp(y)) {
+					t.Fatalf("invalid point: got (%x,%x), want (%x,%x)", xRec, yRec, x, y)
+				}
+			}
+
+			// the round trip should give back the original curve
+			if elliptic.ToStd(curve) != std {
+				t.Fatal("ToStd(FromStd(curve)) should be curve")
+			}
+		})
+	}
+}
+
+func TestToStd(t *testing.T) {
+	curve := elliptic.P256k1()
+	std := elliptic.ToStd(curve)
+
+	if *std.Params() != (stdElliptic.CurveParams{
+		P:       curve.Params().P,
+		N:       curve.Params().N,
+		B:       curve.Params().B,
+		Gx:      curve.Params().Gx,
+		Gy:      curve.Params().Gy,
+		BitSize: curve.Params().BitSize,
+		Name:    curve.Params().Name,
+	}) {
+		t.Fatal("the parameters should be the same")
+	}
+
+	digest := sha256.Sum256([]byte("test message"))
+
+	t.Run("std signs, local verifies", func(t *testing.T) {
+		priv, err := stdECDSA.GenerateKey(std, rand.Reader)
+		if nil != err {
+			t.Fatal(err)
+		}
+		r, s, err := stdECDSA.Sign(rand.Reader, priv, digest[:])
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		pub := &ecdsa.PublicKey{Curve: curve, X: priv.X, Y: priv.Y}
+		if !ecdsa.Verify(pub, digest[:], r, s) {
+			t.Fatal("the signature by crypto/ecdsa should be valid")
+		}
+	})
+
+	t.Run("local signs, std verifies", func(t *testing.T) {
+		priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if nil != err {
+			t.Fatal(err)
+		}
+		r, s, err := ecdsa.Sign(rand.Reader, priv, digest[:])
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		pub := &stdECDSA.PublicKey{Curve: std, X: priv.X, Y: priv.Y}
+		if !stdECDSA.Verify(pub, digest[:], r, s) {
+			t.Fatal("the signature should be valid for crypto/ecdsa")
+		}
+	})
+
+	// the round trip should give back the original curve
+	if elliptic.FromStd(std) != curve {
+		t.Fatal("FromStd(ToStd(curve)) should be curve")
+	}
+}
@@ -10,15 +10,32 @@
 package elliptic
 
 import (
-	"crypto/elliptic"
 	"io"
 	"math/big"
+
+	"github.com/sammy00/crypto/misc"
 )
 
-// CurveParams aliases elliptic.CurveParams without any bounded methods
-type CurveParams elliptic.CurveParams
+// CurveParams mirrors elliptic.CurveParams without any bounded methods, and
+// extends it with the cofactor of the curve
+type CurveParams struct {
+	P       *big.Int // the order of the underlying field
+	N       *big.Int // the order of the base point
+	B       *big.Int // the constant of the curve equation
+	Gx, Gy  *big.Int // (x,y) of the base point
+	BitSize int      // the size of the underlying field
+	Name    string   // the canonical name of the curve
+	H       *big.Int // the cofactor, where nil is taken as 1
+}
 
-// mask serves for key generation
+// prefixes of the point encodings specified in section 4.3.6 of ANSI X9.62
+const (
+	pointCompressed   byte = 0x02 // y bit + x coord
+	pointUncompressed byte = 0x04 // x coord + y coord
+	pointHybrid       byte = 0x06 // y bit + x coord + y coord
+)
+
+// mask clears the excess bits of the first byte in key generation
 var mask = []byte{0xff, 0x1, 0x3, 0x7, 0xf, 0x1f, 0x3f, 0x7f}
 
 // Curve specifies the necessary api for elliptic curves
@@ -41,33 +58,11 @@ type Curve interface {
 	DecompressPoint(x *big.Int, yOdd bool) (*big.Int, error)
 }
 
-// GenerateKey returns a public/private key pair. The private key is generated using the given reader, which must return random data.
+// GenerateKey returns a public/private key pair. The private key is generated
+// using the given reader, which must return random data, by the method
+// ExtraRandomBits of GenerateKeyWithMethod.
 func GenerateKey(curve Curve, rand io.Reader) (priv []byte, x, y *big.Int, err error) {
-	N := curve.Params().N
-	bitSize := N.BitLen()
-	byteLen := (bitSize + 7) >> 3
-	priv = make([]byte, byteLen)
-
-	for x == nil {
-		_, err = io.ReadFull(rand, priv)
-		if err != nil {
-			return
-		}
-		// We have to mask off any excess bits in the case that the size of the
-		// underlying field is not a whole number of bytes.
-		priv[0] &= mask[bitSize%8]
-		// This is because, in tests, rand will return all zeros and we don't
-		// want to get the point at infinity and loop forever.
-		priv[1] ^= 0x42
-
-		// If the scalar is out of range, sample another random number.
-		if new(big.Int).SetBytes(priv).Cmp(N) >= 0 {
-			continue
-		}
-
-		x, y = curve.ScalarBaseMult(priv)
-	}
-	return
+	return GenerateKeyWithMethod(curve, rand, ExtraRandomBits)
 }
 
 // Marshal converts a point into the uncompressed form specified in section 4.3.6 of ANSI X9.62.
@@ -75,7 +70,7 @@ func Marshal(curve Curve, x, y *big.Int) []byte {
 	byteLen := (curve.Params().BitSize + 7) >> 3
 
 	ret := make([]byte, 1+2*byteLen)
-	ret[0] = 4 // uncompressed point
+	ret[0] = pointUncompressed
 
 	xBytes := x.Bytes()
 	copy(ret[1+byteLen-len(xBytes):], xBytes)
@@ -92,7 +87,7 @@ func Unmarshal(curve Curve, data []byte) (x, y *big.Int) {
 	if len(data) != 1+2*byteLen {
 		return
 	}
-	if data[0] != 4 { // uncompressed form
+	if data[0] != pointUncompressed { // uncompressed form
 		return
 	}
 	p := curve.Params().P
@@ -107,3 +102,135 @@ func Unmarshal(curve Curve, data []byte) (x, y *big.Int) {
 
 	return
 }
+
+// MarshalCompressed converts a point into the compressed form specified in
+// section 4.3.6 of ANSI X9.62.
+func MarshalCompressed(curve Curve, x, y *big.Int) []byte {
+	byteLen := (curve.Params().BitSize + 7) >> 3
+
+	ret := make([]byte, 1+byteLen)
+	ret[0] = pointCompressed | byte(y.Bit(0))
+	misc.ReverseCopy(ret[1:], x.Bytes())
+
+	return ret
+}
+
+// UnmarshalCompressed converts a point, serialized by MarshalCompressed, into
+// an (x,y) pair. It is an error if the point is not in compressed form or is
+// not on the curve. On error, x = nil.
+func UnmarshalCompressed(curve Curve, data []byte) (x, y *big.Int) {
+	byteLen := (curve.Params().BitSize + 7) >> 3
+	if len(data) != 1+byteLen {
+		return
+	}
+	if (data[0] & 0xfe) != pointCompressed { // compressed form
+		return
+	}
+	p := curve.Params().P
+	x = new(big.Int).SetBytes(data[1:])
+	if x.Cmp(p) >= 0 {
+		return nil, nil
+	}
+	y, err := curve.DecompressPoint(x, 0x01 == (data[0]&0x01))
+	if nil != err {
+		return nil, nil
+	}
+	if !curve.IsOnCurve(x, y) {
+		return nil, nil
+	}
+
+	return
+}
+
+// UnmarshalAny converts a point in any of the compressed, uncompressed and
+// hybrid forms specified in section 4.3.6 of ANSI X9.62 into an (x,y) pair,
+// dispatching on the leading tag. For the hybrid form, the parity of y must
+// match the one told by the tag. It is an error if the point is not on the
+// curve. On error, x = nil.
+func UnmarshalAny(curve Curve, data []byte) (x, y *big.Int) {
+	if 0 == len(data) {
+		return
+	}
+
+	switch data[0] & 0xfe {
+	case pointCompressed:
+		return UnmarshalCompressed(curve, data)
+	case pointUncompressed:
+		// only 0x04 is valid here, which Unmarshal checks
+		return Unmarshal(curve, data)
+	case pointHybrid:
+		return unmarshalHybrid(curve, data)
+	}
+
+	return
+}
+
+// unmarshalHybrid converts a point in the hybrid form into an (x,y) pair, and
+// cross-checks the parity of y against the tag. On error, x = nil.
+func unmarshalHybrid(curve Curve, data []byte) (x, y *big.Int) {
+	byteLen := (curve.Params().BitSize + 7) >> 3
+	if len(data) != 1+2*byteLen {
+		return
+	}
+	if (data[0] & 0xfe) != pointHybrid { // hybrid form
+		return
+	}
+
+	// the hybrid form is the uncompressed one with the parity of y
+	uncompressed := make([]byte, len(data))
+	copy(uncompressed, data)
+	uncompressed[0] = pointUncompressed
+	if x, y = Unmarshal(curve, uncompressed); nil == x {
+		return
+	}
+
+	if y.Bit(0) != uint(data[0]&0x01) {
+		return nil, nil
+	}
+
+	return
+}
+
+// Cofactor returns the cofactor of the curve, which defaults to 1 if
+// unspecified
+func Cofactor(curve Curve) *big.Int {
+	if h := curve.Params().H; nil != h {
+		return h
+	}
+
+	return new(big.Int).SetInt64(1)
+}
+
+// ClearCofactor maps the point (x,y) on the curve into the prime-order
+// subgroup by multiplying it with the cofactor
+func ClearCofactor(curve Curve, x, y *big.Int) (xOut, yOut *big.Int) {
+	h := Cofactor(curve)
+	if 1 == h.BitLen() {
+		return new(big.Int).Set(x), new(big.Int).Set(y)
+	}
+
+	return curve.ScalarMult(x, y, h.Bytes())
+}
+
+// IsInSubgroup reports whether (x,y) is on the curve and lies in the
+// subgroup of prime order N generated by the base point, excluding the point
+// at infinity. For curves with a cofactor of 1, every point on the curve
+// qualifies, and the check boils down to IsOnCurve.
+func IsInSubgroup(curve Curve, x, y *big.Int) bool {
+	params := curve.Params()
+	if (x.Sign() < 0) || (x.Cmp(params.P) >= 0) ||
+		(y.Sign() < 0) || (y.Cmp(params.P) >= 0) {
+		return false
+	}
+	if !curve.IsOnCurve(x, y) {
+		return false
+	}
+
+	if 1 == Cofactor(curve).BitLen() {
+		return true
+	}
+
+	// N*(x,y) should be the point at infinity
+	xN, yN := curve.ScalarMult(x, y, params.N.Bytes())
+	return (0 == xN.Sign()) && (0 == yN.Sign())
+}
@@ -0,0 +1,82 @@
+package elliptic_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/sammy00/crypto/elliptic"
+)
+
+// toyCurve returns y^2 = x^3 + 8 over GF(60037), which has 4*14887 points
+func toyCurve() elliptic.Curve {
+	return &elliptic.KoblitzCurve{
+		CurveParams: &elliptic.CurveParams{
+			P:       big.NewInt(60037),
+			N:       big.NewInt(14887),
+			B:       big.NewInt(8),
+			Gx:      big.NewInt(24235),
+			Gy:      big.NewInt(5086),
+			BitSize: 16,
+			Name:    "toy",
+			H:       big.NewInt(4),
+		},
+	}
+}
+
+func TestCofactor(t *testing.T) {
+	if h := elliptic.Cofactor(elliptic.P256k1()); 0 != h.Cmp(big.NewInt(1)) {
+		t.Fatalf("invalid cofactor of secp256k1: got %s, want 1", h)
+	}
+
+	if h := elliptic.Cofactor(toyCurve()); 0 != h.Cmp(big.NewInt(4)) {
+		t.Fatalf("invalid cofactor of the toy curve: got %s, want 4", h)
+	}
+
+	// an unspecified cofactor is taken as 1
+	curve := &elliptic.KoblitzCurve{CurveParams: &elliptic.CurveParams{}}
+	if h := elliptic.Cofactor(curve); 0 != h.Cmp(big.NewInt(1)) {
+		t.Fatalf("invalid default cofactor: got %s, want 1", h)
+	}
+}
+
+func TestIsInSubgroup(t *testing.T) {
+	curve := toyCurve()
+	params := curve.Params()
+
+	testCases := []struct {
+		name   string
+		x, y   *big.Int
+		expect bool
+	}{
+		{"base point", params.Gx, params.Gy, true},
+		{"point of order 2", big.NewInt(60035), big.NewInt(0), false},
+		{"point of order 2N", big.NewInt(2), big.NewInt(4), false},
+		{"off curve", big.NewInt(2), big.NewInt(5), false},
+		{"infinity", big.NewInt(0), big.NewInt(0), false},
+		{"x out of range", new(big.Int).Add(params.Gx, params.P), params.Gy, false},
+	}
+
+	for _, c := range testCases {
+		if got := elliptic.IsInSubgroup(curve, c.x, c.y); got != c.expect {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.expect)
+		}
+	}
+
+	// clearing the cofactor should map points into the subgroup
+	x, y := elliptic.ClearCofactor(curve, big.NewInt(2), big.NewInt(4))
+	if !elliptic.IsInSubgroup(curve, x, y) {
+		t.Errorf("(%s,%s) should be in the subgroup", x, y)
+	}
+
+	// and the point of order 2 should vanish
+	x, y = elliptic.ClearCofactor(curve, big.NewInt(60035), big.NewInt(0))
+	if (0 != x.Sign()) || (0 != y.Sign()) {
+		t.Errorf("invalid h*(60035,0): got (%s,%s), want (0,0)", x, y)
+	}
+
+	// every point on secp256k1 lies in the subgroup
+	secp256k1 := elliptic.P256k1()
+	if !elliptic.IsInSubgroup(secp256k1, secp256k1.Params().Gx, secp256k1.Params().Gy) {
+		t.Error("the base point of secp256k1 should be in the subgroup")
+	}
+}
@@ -0,0 +1,136 @@
+package elliptic
+
+// References:
+//   [RFC7836]: Guidelines on the Cryptographic Algorithms to Accompany the
+//     Usage of Standards GOST R 34.10-2012 and GOST R 34.11-2012,
+//     https://tools.ietf.org/html/rfc7836
+//   [RFC4357]: Additional Cryptographic Algorithms for Use with GOST
+//     28147-89, GOST R 34.10-94, GOST R 34.10-2001, and GOST R 34.11-94
+//     Algorithms, https://tools.ietf.org/html/rfc4357
+
+import (
+	"math/big"
+	"sync"
+)
+
+var (
+	// gostInitOncer serves for one-time-only initialization of all the
+	// GOST R 34.10-2012 curves
+	gostInitOncer sync.Once
+	// the unexported curves which can be captured by the GOST* functions
+	gost256A, gost256B, gost512A, gost512B, gost512C *WeierstrassCurve
+)
+
+// GOST256A returns the handle of id-tc26-gost-3410-2012-256-paramSetA of
+// [RFC7836], given in the Weierstrass form of the twisted Edwards curve with
+// a cofactor of 4
+func GOST256A() Curve {
+	gostInitOncer.Do(initGOST)
+	return gost256A
+}
+
+// GOST256B returns the handle of id-tc26-gost-3410-2012-256-paramSetB, a.k.a.
+// id-GostR3410-2001-CryptoPro-A-ParamSet of [RFC4357]
+func GOST256B() Curve {
+	gostInitOncer.Do(initGOST)
+	return gost256B
+}
+
+// GOST512A returns the handle of id-tc26-gost-3410-2012-512-paramSetA of
+// [RFC7836]
+func GOST512A() Curve {
+	gostInitOncer.Do(initGOST)
+	return gost512A
+}
+
+// GOST512B returns the handle of id-tc26-gost-3410-2012-512-paramSetB of
+// [RFC7836]
+func GOST512B() Curve {
+	gostInitOncer.Do(initGOST)
+	return gost512B
+}
+
+// GOST512C returns the handle of id-tc26-gost-3410-2012-512-paramSetC of
+// [RFC7836], given in the Weierstrass form of the twisted Edwards curve with
+// a cofactor of 4
+func GOST512C() Curve {
+	gostInitOncer.Do(initGOST)
+	return gost512C
+}
+
+func initGOST() {
+	gost256A = newWeierstrassCurve("id-tc26-gost-3410-2012-256-paramSetA", 256, 4,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFD97",
+		"C2173F1513981673AF4892C23035A27CE25E2013BF95AA33B22C656F277E7335",
+		"295F9BAE7428ED9CCC20E7C359A9D41A22FCCD9108E17BF7BA9337A6F8AE9513",
+		"400000000000000000000000000000000FD8CDDFC87B6635C115AF556C360C67",
+		"91E38443A5E82C0D880923425712B2BB658B9196932E02C78B2582FE742DAA28",
+		"32879423AB1A0375895786C4BB46E9565FDE0B5344766740AF268ADB32322E5C")
+
+	gost256B = newWeierstrassCurve("id-tc26-gost-3410-2012-256-paramSetB", 256, 1,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFD97",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFD94",
+		"A6",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF6C611070995AD10045841B09B761B893",
+		"01",
+		"8D91E471E0989CDA27DF505A453F2B7635294F2DDF23E3B122ACC99C9E9F1E14")
+
+	gost512A = newWeierstrassCurve("id-tc26-gost-3410-2012-512-paramSetA", 512, 1,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"+
+			"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFDC7",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"+
+			"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFDC4",
+		"E8C2505DEDFC86DDC1BD0B2B6667F1DA34B82574761CB0E879BD081CFD0B6265"+
+			"EE3CB090F30D27614CB4574010DA90DD862EF9D4EBEE4761503190785A71C760",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"+
+			"27E69532F48D89116FF22B8D4E0560609B4B38ABFAD2B85DCACDB1411F10B275",
+		"03",
+		"7503CFE87A836AE3A61B8816E25450E6CE5E1C93ACF1ABC1778064FDCBEFA921"+
+			"DF1626BE4FD036E93D75E6A50E3A41E98028FE5FC235F5B889A589CB5215F2A4")
+
+	gost512B = newWeierstrassCurve("id-tc26-gost-3410-2012-512-paramSetB", 512, 1,
+		"8000000000000000000000000000000000000000000000000000000000000000"+
+			"000000000000000000000000000000000000000000000000000000000000006F",
+		"8000000000000000000000000000000000000000000000000000000000000000"+
+			"000000000000000000000000000000000000000000000000000000000000006C",
+		"687D1B459DC841457E3E06CF6F5E2517B97C7D614AF138BCBF85DC806C4B289F"+
+			"3E965D2DB1416D217F8B276FAD1AB69C50F78BEE1FA3106EFB8CCBC7C5140116",
+		"8000000000000000000000000000000000000000000000000000000000000001"+
+			"49A1EC142565A545ACFDB77BD9D40CFA8B996712101BEA0EC6346C54374F25BD",
+		"02",
+		"1A8F7EDA389B094C2C071E3647A8940F3C123B697578C213BE6DD9E6C8EC7335"+
+			"DCB228FD1EDF4A39152CBCAAF8C0398828041055F94CEEEC7E21340780FE41BD")
+
+	gost512C = newWeierstrassCurve("id-tc26-gost-3410-2012-512-paramSetC", 512, 4,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"+
+			"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFDC7",
+		"DC9203E514A721875485A529D2C722FB187BC8980EB866644DE41C68E1430645"+
+			"46E861C0E2C9EDD92ADE71F46FCF50FF2AD97F951FDA9F2A2EB6546F39689BD3",
+		"B4C4EE28CEBC6C2C8AC12952CF37F16AC7EFB6A9F69F4B57FFDA2E4F0DE5ADE0"+
+			"38CBC2FFF719D2C18DE0284B8BFEF3B52B8CC7A5F5BF0A3C8D2319A5312557E1",
+		"3FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"+
+			"C98CDBA46506AB004C33A9FF5147502CC8EDA9E7A769A12694623CEF47F023ED",
+		"E2E31EDFC23DE7BDEBE241CE593EF5DE2295B7A9CBAEF021D385F7074CEA043A"+
+			"A27272A7AE602BF2A7B9033DB9ED3610C6FB85487EAE97AAC5BC7928C1950148",
+		"F5CE40D95B5EB899ABBCCFF5911CB8577939804D6527378B8C108C3D2090FF9B"+
+			"E18E2D33E3021ED2EF32D85822423B6304F726AA854BAE07D0396E9A9ADDC40F")
+}
+
+// newWeierstrassCurve builds the curve from the hex-encoded parameters
+func newWeierstrassCurve(name string, bitSize int, h int64,
+	p, a, b, n, gx, gy string) *WeierstrassCurve {
+	params := &CurveParams{
+		Name:    name,
+		BitSize: bitSize,
+		H:       big.NewInt(h),
+	}
+	params.P, _ = new(big.Int).SetString(p, 16)
+	params.B, _ = new(big.Int).SetString(b, 16)
+	params.N, _ = new(big.Int).SetString(n, 16)
+	params.Gx, _ = new(big.Int).SetString(gx, 16)
+	params.Gy, _ = new(big.Int).SetString(gy, 16)
+
+	A, _ := new(big.Int).SetString(a, 16)
+
+	return &WeierstrassCurve{CurveParams: params, A: A}
+}
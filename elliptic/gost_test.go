@@ -0,0 +1,54 @@
+package elliptic_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/sammy00/crypto/elliptic"
+)
+
+func TestGOSTCurves(t *testing.T) {
+	curves := []elliptic.Curve{
+		elliptic.GOST256A(),
+		elliptic.GOST256B(),
+		elliptic.GOST512A(),
+		elliptic.GOST512B(),
+		elliptic.GOST512C(),
+	}
+
+	for _, curve := range curves {
+		params := curve.Params()
+		t.Run(params.Name, func(t *testing.T) {
+			if !elliptic.IsInSubgroup(curve, params.Gx, params.Gy) {
+				t.Fatal("the base point should be in the subgroup")
+			}
+
+			// 2G+G = 3G, which goes through both of Double and Add
+			x2, y2 := curve.Double(params.Gx, params.Gy)
+			x3, y3 := curve.Add(x2, y2, params.Gx, params.Gy)
+			if xx, yy := curve.ScalarBaseMult([]byte{3}); (0 != xx.Cmp(x3)) || (0 != yy.Cmp(y3)) {
+				t.Fatal("3G mismatches 2G+G")
+			}
+
+			// G+G should fall back to doubling, and G-G should be infinity
+			if xx, yy := curve.Add(params.Gx, params.Gy, params.Gx, params.Gy); (0 != xx.Cmp(x2)) || (0 != yy.Cmp(y2)) {
+				t.Fatal("G+G mismatches 2G")
+			}
+			negY := new(big.Int).Sub(params.P, params.Gy)
+			if xx, yy := curve.Add(params.Gx, params.Gy, params.Gx, negY); (0 != xx.Sign()) || (0 != yy.Sign()) {
+				t.Fatal("G-G should be the infinity")
+			}
+
+			_, x, y, err := elliptic.GenerateKey(curve, rand.Reader)
+			if nil != err {
+				t.Fatal(err)
+			}
+			data := elliptic.MarshalCompressed(curve, x, y)
+			xRec, yRec := elliptic.UnmarshalCompressed(curve, data)
+			if (nil == xRec) || (0 != xRec.Cmp(x)) || (0 != yRec.Cmp(y)) {
+				t.Fatalf("invalid point: got (%x,%x), want (%x,%x)", xRec, yRec, x, y)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package elliptic
+
+// References:
+//   [FIPS186-5]: Digital Signature Standard (DSS), appendix A.2,
+//     https://doi.org/10.6028/NIST.FIPS.186-5
+
+import (
+	"errors"
+	"io"
+	"math/big"
+)
+
+// KeyGenMethod selects how a private scalar in [1,N-1] is derived from the
+// random bits, following appendix A.2 of [FIPS186-5]
+type KeyGenMethod int
+
+const (
+	// ExtraRandomBits draws a c of N.BitLen()+64 bits, and takes
+	// d = (c mod (N-1)) + 1 as is specified in A.2.1, whose bias is
+	// negligible thanks to the 64 extra bits
+	ExtraRandomBits KeyGenMethod = iota
+	// RejectionSampling draws a c of N.BitLen() bits until c <= N-2, and
+	// takes d = c + 1 as is specified in A.2.2
+	RejectionSampling
+)
+
+// RandScalar draws a private scalar in [1,N-1] from rand with the given
+// method. Since d is at least 1 by construction, a broken rand outputting
+// nothing but zeros gives d = 1 rather than looping forever.
+func RandScalar(curve Curve, rand io.Reader, method KeyGenMethod) (*big.Int, error) {
+	N := curve.Params().N
+	nMinus1 := new(big.Int).Sub(N, big.NewInt(1))
+
+	switch method {
+	case ExtraRandomBits:
+		c, err := randBits(rand, N.BitLen()+64)
+		if nil != err {
+			return nil, err
+		}
+
+		c.Mod(c, nMinus1)
+		return c.Add(c, big.NewInt(1)), nil
+	case RejectionSampling:
+		for {
+			c, err := randBits(rand, N.BitLen())
+			if nil != err {
+				return nil, err
+			}
+
+			// c <= N-2
+			if c.Cmp(nMinus1) < 0 {
+				return c.Add(c, big.NewInt(1)), nil
+			}
+		}
+	}
+
+	return nil, errors.New("elliptic: unknown key generation method")
+}
+
+// randBits reads an integer of the given number of bits from rand, where
+// the excess high bits of the first byte are masked off
+func randBits(rand io.Reader, bits int) (*big.Int, error) {
+	b := make([]byte, (bits+7)/8)
+	if _, err := io.ReadFull(rand, b); nil != err {
+		return nil, err
+	}
+	b[0] &= mask[bits%8]
+
+	c := new(big.Int).SetBytes(b)
+	for i := range b {
+		b[i] = 0
+	}
+
+	return c, nil
+}
+
+// GenerateKeyWithMethod returns a public/private key pair, whose private
+// scalar is drawn by the given method and encoded as long as N
+func GenerateKeyWithMethod(curve Curve, rand io.Reader,
+	method KeyGenMethod) (priv []byte, x, y *big.Int, err error) {
+	d, err := RandScalar(curve, rand, method)
+	if nil != err {
+		return nil, nil, nil, err
+	}
+
+	priv = make([]byte, (curve.Params().N.BitLen()+7)/8)
+	dBytes := d.Bytes()
+	copy(priv[len(priv)-len(dBytes):], dBytes)
+
+	x, y = curve.ScalarBaseMult(priv)
+
+	return priv, x, y, nil
+}
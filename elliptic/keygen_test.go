@@ -0,0 +1,123 @@
+package elliptic_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/sammy00/crypto/elliptic"
+)
+
+// constReader outputs nothing but the given byte
+type constReader byte
+
+func (r constReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(r)
+	}
+
+	return len(p), nil
+}
+
+func TestRandScalarZeroRand(t *testing.T) {
+	curve := elliptic.P256k1()
+
+	methods := []elliptic.KeyGenMethod{elliptic.ExtraRandomBits, elliptic.RejectionSampling}
+	for _, method := range methods {
+		d, err := elliptic.RandScalar(curve, constReader(0), method)
+		if nil != err {
+			t.Fatal(err)
+		} else if 1 != d.Int64() {
+			t.Fatalf("method %d: invalid scalar: got %v, want 1", method, d)
+		}
+	}
+
+	// no more point at infinity or endless loop without any test hack
+	priv, x, y, err := elliptic.GenerateKey(curve, constReader(0))
+	if nil != err {
+		t.Fatal(err)
+	}
+	if want := append(make([]byte, 31), 0x01); !bytes.Equal(priv, want) {
+		t.Fatalf("invalid private key: got %x, want %x", priv, want)
+	}
+	if (0 != x.Cmp(curve.Params().Gx)) || (0 != y.Cmp(curve.Params().Gy)) {
+		t.Fatal("the public key should be the base point")
+	}
+}
+
+func TestRandScalarExtraRandomBits(t *testing.T) {
+	curve := elliptic.P256k1()
+	N := curve.Params().N
+
+	// c = 2^(256+64)-1, d = (c mod (N-1)) + 1
+	c := new(big.Int).Lsh(big.NewInt(1), uint(N.BitLen()+64))
+	c.Sub(c, big.NewInt(1))
+	want := c.Mod(c, new(big.Int).Sub(N, big.NewInt(1)))
+	want.Add(want, big.NewInt(1))
+
+	d, err := elliptic.RandScalar(curve, constReader(0xff), elliptic.ExtraRandomBits)
+	if nil != err {
+		t.Fatal(err)
+	} else if 0 != d.Cmp(want) {
+		t.Fatalf("invalid scalar: got %x, want %x", d, want)
+	}
+}
+
+func TestRandScalarRejectionSampling(t *testing.T) {
+	curve := elliptic.P256k1()
+
+	// 2^256-1 > N-2 is rejected, and the second draw of 2 gives d = 3
+	in := append(bytes.Repeat([]byte{0xff}, 32), append(make([]byte, 31), 0x02)...)
+	r := bytes.NewReader(in)
+
+	d, err := elliptic.RandScalar(curve, r, elliptic.RejectionSampling)
+	if nil != err {
+		t.Fatal(err)
+	} else if 3 != d.Int64() {
+		t.Fatalf("invalid scalar: got %v, want 3", d)
+	}
+	if 0 != r.Len() {
+		t.Fatalf("%d bytes are left unread", r.Len())
+	}
+
+	if _, err := elliptic.RandScalar(curve, bytes.NewReader(in[:32]),
+		elliptic.RejectionSampling); io.EOF != err {
+		t.Fatalf("the reading error should be reported: got %v", err)
+	}
+}
+
+func TestRandScalarRange(t *testing.T) {
+	// a toy curve of 14887 points, so that the whole range gets hit
+	curve := &elliptic.KoblitzCurve{
+		CurveParams: &elliptic.CurveParams{
+			P:       big.NewInt(60037),
+			N:       big.NewInt(14887),
+			B:       big.NewInt(8),
+			Gx:      big.NewInt(24235),
+			Gy:      big.NewInt(5086),
+			BitSize: 16,
+			Name:    "toy",
+			H:       big.NewInt(4),
+		},
+	}
+	N := curve.Params().N
+
+	methods := []elliptic.KeyGenMethod{elliptic.ExtraRandomBits, elliptic.RejectionSampling}
+	for _, method := range methods {
+		for i := 0; i < 1000; i++ {
+			d, err := elliptic.RandScalar(curve, rand.Reader, method)
+			if nil != err {
+				t.Fatal(err)
+			}
+			if (d.Sign() <= 0) || (d.Cmp(N) >= 0) {
+				t.Fatalf("method %d: the scalar %v is out of [1,N-1]", method, d)
+			}
+		}
+	}
+
+	if _, err := elliptic.RandScalar(curve, rand.Reader, elliptic.KeyGenMethod(42)); nil == err {
+		t.Fatal("the unknown method should be rejected")
+	}
+}
@@ -35,7 +35,7 @@ var (
 	// all internal KoblitzCurve instances
 	koblitzInitOncer sync.Once
 	// secp256k1 is an unexported KoblitzCurve which can be captured by P256k1()
-	secp256k1 *KoblitzCurve
+	secp256k1 *secp256k1Curve
 )
 
 // KoblitzCurve embeds the parameters of an elliptic curve and
@@ -64,6 +64,9 @@ func (curve *KoblitzCurve) DecompressPoint(x *big.Int, yOdd bool) (*big.Int, err
 	x3.Mod(x3, params.P) // normalize x3
 
 	y := new(big.Int).ModSqrt(x3, params.P)
+	if nil == y {
+		return nil, errors.New("x is not on the curve")
+	}
 
 	if misc.IsOdd(y) != yOdd {
 		y.Sub(params.P, y)
@@ -72,8 +75,6 @@ func (curve *KoblitzCurve) DecompressPoint(x *big.Int, yOdd bool) (*big.Int, err
 		return nil, errors.New("oddness of y is wrong")
 	}
 
-	// check against on curve???
-
 	return y, nil
 }
 
@@ -174,6 +175,13 @@ func (curve *KoblitzCurve) addJacobian(x1, y1, z1, x2, y2, z2 *big.Int) (x, y, z
 	if -1 == h.Sign() {
 		h.Add(h, curve.P) // normalise the field value
 	}
+	if 0 == h.Sign() {
+		if s1.Cmp(s2) == 0 {
+			// (x1,y1,z1) == (x2,y2,z2)
+			return curve.doubleJacobian(x1, y1, z1)
+		}
+		// (x1,y1,z1) == -(x2,y2,z2), z = 0 follows as the point at infinity
+	}
 	// i = (2*H)^2
 	i := new(big.Int).Lsh(h, 1)
 	i.Mul(i, i)
@@ -299,7 +307,7 @@ func initAll() {
 }
 
 func initP256K1() {
-	secp256k1 = new(KoblitzCurve)
+	secp256k1 = &secp256k1Curve{new(KoblitzCurve)}
 
 	params := &CurveParams{
 		Name: "secp256k1",
@@ -310,6 +318,7 @@ func initP256K1() {
 	params.Gx, _ = new(big.Int).SetString("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798", 16)
 	params.Gy, _ = new(big.Int).SetString("483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8", 16)
 	params.BitSize = 256
+	params.H = new(big.Int).SetInt64(1)
 
 	secp256k1.CurveParams = params
 }
@@ -1,6 +1,7 @@
 package elliptic_test
 
 import (
+	"crypto/rand"
 	"fmt"
 	"math/big"
 	"testing"
@@ -125,3 +126,180 @@ func TestKoblitzScalarBaseMult(t *testing.T) {
 		}
 	}
 }
+
+// TestSecp256k1AgainstGeneric checks the dedicated secp256k1 arithmetic
+// against the generic KoblitzCurve
+func TestSecp256k1AgainstGeneric(t *testing.T) {
+	curve := elliptic.P256k1()
+	generic := &elliptic.KoblitzCurve{CurveParams: curve.Params()}
+	params := curve.Params()
+
+	for i := 0; i < 32; i++ {
+		_, x1, y1, err := elliptic.GenerateKey(curve, rand.Reader)
+		if nil != err {
+			t.Fatal(err)
+		}
+		k, _, _, err := elliptic.GenerateKey(curve, rand.Reader)
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		x2, y2 := generic.ScalarMult(x1, y1, k)
+		if x, y := curve.ScalarMult(x1, y1, k); (0 != x.Cmp(x2)) || (0 != y.Cmp(y2)) {
+			t.Fatalf("#%d: invalid ScalarMult: got (%x,%x), want (%x,%x)", i, x, y, x2, y2)
+		}
+
+		xSum, ySum := generic.Add(x1, y1, x2, y2)
+		if x, y := curve.Add(x1, y1, x2, y2); (0 != x.Cmp(xSum)) || (0 != y.Cmp(ySum)) {
+			t.Fatalf("#%d: invalid Add: got (%x,%x), want (%x,%x)", i, x, y, xSum, ySum)
+		}
+
+		xDbl, yDbl := generic.Double(x1, y1)
+		if x, y := curve.Double(x1, y1); (0 != x.Cmp(xDbl)) || (0 != y.Cmp(yDbl)) {
+			t.Fatalf("#%d: invalid Double: got (%x,%x), want (%x,%x)", i, x, y, xDbl, yDbl)
+		}
+
+		// adding a point to itself should double it
+		if x, y := curve.Add(x1, y1, x1, y1); (0 != x.Cmp(xDbl)) || (0 != y.Cmp(yDbl)) {
+			t.Fatalf("#%d: invalid P+P: got (%x,%x), want (%x,%x)", i, x, y, xDbl, yDbl)
+		}
+		if x, y := generic.Add(x1, y1, x1, y1); (0 != x.Cmp(xDbl)) || (0 != y.Cmp(yDbl)) {
+			t.Fatalf("#%d: invalid generic P+P: got (%x,%x), want (%x,%x)", i, x, y, xDbl, yDbl)
+		}
+
+		// adding a point to its negation should give the point at the infinity
+		yNeg := new(big.Int).Sub(params.P, y1)
+		if x, y := curve.Add(x1, y1, x1, yNeg); (0 != x.Sign()) || (0 != y.Sign()) {
+			t.Fatalf("#%d: invalid P-P: got (%x,%x), want (0,0)", i, x, y)
+		}
+
+		// DecompressPoint should agree for both parities, as well as for x
+		// off the curve
+		for _, x := range []*big.Int{x1, new(big.Int).Add(x1, big.NewInt(1))} {
+			for _, yOdd := range []bool{false, true} {
+				y, err := curve.DecompressPoint(x, yOdd)
+				yGeneric, errGeneric := generic.DecompressPoint(x, yOdd)
+				if (nil == err) != (nil == errGeneric) {
+					t.Fatalf("#%d: invalid DecompressPoint error: got %v, want %v", i, err, errGeneric)
+				}
+				if (nil == err) && (0 != y.Cmp(yGeneric)) {
+					t.Fatalf("#%d: invalid DecompressPoint: got %x, want %x", i, y, yGeneric)
+				}
+			}
+		}
+	}
+}
+
+// secp192k1 helps to test curves other than secp256k1
+func secp192k1() elliptic.Curve {
+	params := &elliptic.CurveParams{Name: "secp192k1", BitSize: 192}
+	params.P, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFEE37", 16)
+	params.N, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFE26F2FC170F69466A74DEFD8D", 16)
+	params.B = new(big.Int).SetInt64(3)
+	params.Gx, _ = new(big.Int).SetString("DB4FF10EC057E9AE26B07D0280B7F4341DA5D1B1EAE06C7D", 16)
+	params.Gy, _ = new(big.Int).SetString("9B2F2F6D9C5628A7844163D015BE86344082AA88D95E2F9D", 16)
+
+	return &elliptic.KoblitzCurve{CurveParams: params}
+}
+
+func TestKoblitzCompressedMarshaling(t *testing.T) {
+	curves := map[string]elliptic.Curve{
+		"secp192k1": secp192k1(),
+		"secp256k1": elliptic.P256k1(),
+	}
+
+	for name, curve := range curves {
+		t.Run(name, func(t *testing.T) {
+			byteLen := (curve.Params().BitSize + 7) / 8
+
+			for i := 0; i < 16; i++ {
+				_, x, y, err := elliptic.GenerateKey(curve, rand.Reader)
+				if nil != err {
+					t.Fatal(err)
+				}
+
+				data := elliptic.MarshalCompressed(curve, x, y)
+				if len(data) != 1+byteLen {
+					t.Fatalf("invalid length: got %d, want %d", len(data), 1+byteLen)
+				}
+
+				xRec, yRec := elliptic.UnmarshalCompressed(curve, data)
+				if (nil == xRec) || (0 != x.Cmp(xRec)) || (0 != y.Cmp(yRec)) {
+					t.Fatalf("invalid point: got (%x,%x), want (%x,%x)", xRec, yRec, x, y)
+				}
+
+				// flipping the parity tag should give the negation
+				data[0] ^= 0x01
+				xRec, yRec = elliptic.UnmarshalCompressed(curve, data)
+				if (nil == xRec) || (0 != x.Cmp(xRec)) ||
+					(0 != new(big.Int).Add(y, yRec).Cmp(curve.Params().P)) {
+					t.Fatalf("invalid negation: got (%x,%x)", xRec, yRec)
+				}
+			}
+		})
+	}
+}
+
+func TestKoblitzCompressedMarshalingAgainstBTC(t *testing.T) {
+	curve := elliptic.P256k1()
+
+	for i := 0; i < 16; i++ {
+		priv, err := btcec.NewPrivateKey(btcec.S256())
+		if nil != err {
+			t.Fatal(err)
+		}
+		pub := priv.PubKey()
+
+		if data := elliptic.MarshalCompressed(curve, pub.X, pub.Y); string(data) != string(pub.SerializeCompressed()) {
+			t.Fatalf("invalid compressed form: got %x, want %x", data, pub.SerializeCompressed())
+		}
+
+		for _, data := range [][]byte{pub.SerializeCompressed(),
+			pub.SerializeUncompressed(), pub.SerializeHybrid()} {
+			x, y := elliptic.UnmarshalAny(curve, data)
+			if (nil == x) || (0 != x.Cmp(pub.X)) || (0 != y.Cmp(pub.Y)) {
+				t.Fatalf("invalid point for %x: got (%x,%x), want (%x,%x)", data, x, y, pub.X, pub.Y)
+			}
+		}
+	}
+}
+
+func TestKoblitzUnmarshalAnyInvalid(t *testing.T) {
+	curve := elliptic.P256k1()
+	params := curve.Params()
+
+	uncompressed := elliptic.Marshal(curve, params.Gx, params.Gy)
+	compressed := elliptic.MarshalCompressed(curve, params.Gx, params.Gy)
+
+	// hybrid form with the wrong parity of y
+	hybridBadParity := append([]byte{}, uncompressed...)
+	hybridBadParity[0] = 0x06 | byte(1^params.Gy.Bit(0))
+
+	// x with no corresponding y on the curve
+	offCurve := make([]byte, len(compressed))
+	offCurve[0] = 0x02
+	offCurve[len(offCurve)-1] = 0x05
+
+	// x no less than p
+	xTooLarge := append([]byte{0x02}, params.P.Bytes()...)
+
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"infinity", []byte{0x00}},
+		{"unknown tag", append([]byte{0x05}, uncompressed[1:]...)},
+		{"compressed with bad length", compressed[:len(compressed)-1]},
+		{"uncompressed with bad length", uncompressed[:len(uncompressed)-1]},
+		{"hybrid with bad parity", hybridBadParity},
+		{"compressed off curve", offCurve},
+		{"compressed with x>=p", xTooLarge},
+	}
+
+	for _, c := range testCases {
+		if x, y := elliptic.UnmarshalAny(curve, c.data); (nil != x) || (nil != y) {
+			t.Errorf("%s: both of x and y should be nil", c.name)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package elliptic
+
+import (
+	"math/big"
+)
+
+// msmWindow is the window width in bits of MultiScalarMult
+const msmWindow = 4
+
+// MultiScalarMult calculates k_0*(x_0,y_0) + ... + k_{n-1}*(x_{n-1},y_{n-1})
+// with Straus' interleaving method, where the doublings are shared by all
+// the points. Each scalar is in big-endian form as for ScalarMult. It is
+// much faster than summing up the ScalarMult of every point, especially for
+// secp256k1, whose points stay in Jacobian coordinates all the way.
+func MultiScalarMult(curve Curve, xs, ys []*big.Int, scalars [][]byte) (x, y *big.Int) {
+	if c, ok := curve.(*secp256k1Curve); ok {
+		return c.multiScalarMult(xs, ys, scalars)
+	}
+
+	// tables[i][d] = d*(x_i,y_i) for d in [1,2^msmWindow)
+	tables := make([][1 << msmWindow][2]*big.Int, len(xs))
+	for i := range tables {
+		t := &tables[i]
+		t[1][0], t[1][1] = xs[i], ys[i]
+		t[2][0], t[2][1] = curve.Double(xs[i], ys[i])
+		for d := 3; d < len(t); d++ {
+			t[d][0], t[d][1] = curve.Add(t[d-1][0], t[d-1][1], xs[i], ys[i])
+		}
+	}
+
+	x, y = new(big.Int), new(big.Int)
+	scalarLen := maxLen(scalars)
+	for j := 0; j < 2*scalarLen; j++ {
+		if 0 != j {
+			for k := 0; k < msmWindow; k++ {
+				x, y = curve.Double(x, y)
+			}
+		}
+
+		for i, k := range scalars {
+			if d := nibble(k, scalarLen, j); 0 != d {
+				x, y = curve.Add(x, y, tables[i][d][0], tables[i][d][1])
+			}
+		}
+	}
+
+	return x, y
+}
+
+// multiScalarMult is MultiScalarMult in Jacobian coordinates
+func (curve *secp256k1Curve) multiScalarMult(xs, ys []*big.Int, scalars [][]byte) (x, y *big.Int) {
+	tables := make([][1 << msmWindow]jacobianPoint, len(xs))
+	for i := range tables {
+		t := &tables[i]
+		t[1].fromAffine(xs[i], ys[i])
+		t[2].double(&t[1])
+		for d := 3; d < len(t); d++ {
+			t[d].addMixed(&t[d-1], &t[1])
+		}
+	}
+
+	var acc jacobianPoint
+	scalarLen := maxLen(scalars)
+	for j := 0; j < 2*scalarLen; j++ {
+		if 0 != j {
+			for k := 0; k < msmWindow; k++ {
+				acc.double(&acc)
+			}
+		}
+
+		for i, k := range scalars {
+			if d := nibble(k, scalarLen, j); 0 != d {
+				acc.add(&acc, &tables[i][d])
+			}
+		}
+	}
+
+	return acc.toAffine()
+}
+
+// maxLen returns the max length of the given byte slices
+func maxLen(scalars [][]byte) int {
+	var l int
+	for _, k := range scalars {
+		if len(k) > l {
+			l = len(k)
+		}
+	}
+
+	return l
+}
+
+// nibble returns the j-th 4-bit window from the most significant end of k,
+// which is regarded as padded to scalarLen bytes with leading zeros
+func nibble(k []byte, scalarLen, j int) int {
+	i := j/2 - (scalarLen - len(k))
+	if i < 0 {
+		return 0
+	}
+
+	if 0 == j%2 {
+		return int(k[i] >> 4)
+	}
+	return int(k[i] & 0x0f)
+}
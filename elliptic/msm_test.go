@@ -0,0 +1,61 @@
+package elliptic_test
+
+import (
+	stdElliptic "crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/sammy00/crypto/elliptic"
+)
+
+func TestMultiScalarMult(t *testing.T) {
+	curves := map[string]elliptic.Curve{
+		"secp256k1": elliptic.P256k1(),
+		"P-256":     elliptic.FromStd(stdElliptic.P256()),
+		"toy":       toyCurve(),
+	}
+
+	for name, curve := range curves {
+		t.Run(name, func(t *testing.T) {
+			params := curve.Params()
+
+			for _, n := range []int{1, 2, 7} {
+				xs, ys := make([]*big.Int, n), make([]*big.Int, n)
+				scalars := make([][]byte, n)
+
+				expectX, expectY := new(big.Int), new(big.Int)
+				for i := range xs {
+					k, err := rand.Int(rand.Reader, params.N)
+					if nil != err {
+						t.Fatal(err)
+					}
+					xs[i], ys[i] = curve.ScalarBaseMult(k.Bytes())
+
+					// scalars of mixed lengths, including zero
+					l, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), uint(8*(i%4))))
+					if nil != err {
+						t.Fatal(err)
+					}
+					scalars[i] = l.Bytes()
+
+					x, y := curve.ScalarMult(xs[i], ys[i], scalars[i])
+					expectX, expectY = curve.Add(expectX, expectY, x, y)
+				}
+
+				x, y := elliptic.MultiScalarMult(curve, xs, ys, scalars)
+				if (0 != x.Cmp(expectX)) || (0 != y.Cmp(expectY)) {
+					t.Fatalf("n=%d: got (%x,%x), want (%x,%x)", n, x, y, expectX, expectY)
+				}
+			}
+
+			// P - P is the point at infinity
+			negGy := new(big.Int).Sub(params.P, params.Gy)
+			x, y := elliptic.MultiScalarMult(curve, []*big.Int{params.Gx, params.Gx},
+				[]*big.Int{params.Gy, negGy}, [][]byte{{0x12, 0x34}, {0x12, 0x34}})
+			if (0 != x.Sign()) || (0 != y.Sign()) {
+				t.Fatalf("P-P should be the infinity: got (%x,%x)", x, y)
+			}
+		})
+	}
+}
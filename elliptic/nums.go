@@ -0,0 +1,83 @@
+package elliptic
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+)
+
+// TryAndIncrement maps the seed, interpreted as a big-endian integer, onto a
+// point of the prime-order subgroup. Starting from x = seed mod P, x is
+// incremented until it is the x coordinate of some point, whose y is taken
+// to be even. The point is then multiplied by the cofactor, and the search
+// goes on if the result is the point at infinity.
+//
+// Since the procedure is deterministic and made of public operations only,
+// nobody knows the discrete logarithm of the resulting point w.r.t. the base
+// point, provided the seed is the output of a hash function. For instance,
+// the generator H of Pedersen commitments in secp256k1-zkp is
+// TryAndIncrement(P256k1(), SHA256(Marshal(G))).
+func TryAndIncrement(curve Curve, seed []byte) (x, y *big.Int) {
+	P := curve.Params().P
+
+	x = new(big.Int).SetBytes(seed)
+	x.Mod(x, P)
+
+	one := new(big.Int).SetInt64(1)
+	for ; ; x.Mod(x.Add(x, one), P) {
+		yy, err := curve.DecompressPoint(x, false)
+		if nil != err {
+			continue
+		}
+
+		if xx, yy := ClearCofactor(curve, x, yy); (0 != xx.Sign()) || (0 != yy.Sign()) {
+			return xx, yy
+		}
+	}
+}
+
+// NUMSGenerator derives the index-th "nothing up my sleeve" generator of the
+// curve for the given domain-separation label, which has no known discrete
+// logarithm w.r.t. the base point or any other generator.
+//
+// The derivation is specific to this package, rather than the hash_to_curve
+// of RFC 9380, so other implementations reproduce it only by following the
+// exact layout below. With L = ceil(bitlen(P)/8), the seed is the first L
+// bytes of
+//
+//	SHA256(label || uint32be(index) || uint32be(0)) ||
+//	SHA256(label || uint32be(index) || uint32be(1)) || ...
+//
+// where the label is taken as is, without any length prefix. The generator
+// is then TryAndIncrement(curve, seed), i.e., the seed is read as a
+// big-endian integer and reduced mod P, the y coordinate is the even one,
+// and the cofactor is cleared.
+func NUMSGenerator(curve Curve, label []byte, index uint32) (x, y *big.Int) {
+	seedLen := (curve.Params().P.BitLen() + 7) / 8
+
+	var counters [8]byte
+	binary.BigEndian.PutUint32(counters[:4], index)
+
+	seed := make([]byte, 0, seedLen+sha256.Size)
+	for block := uint32(0); len(seed) < seedLen; block++ {
+		binary.BigEndian.PutUint32(counters[4:], block)
+
+		h := sha256.New()
+		h.Write(label)
+		h.Write(counters[:])
+		seed = h.Sum(seed)
+	}
+
+	return TryAndIncrement(curve, seed[:seedLen])
+}
+
+// NUMSGenerators derives the first n generators of NUMSGenerator for the
+// given label, as is needed by vector commitments such as Bulletproofs
+func NUMSGenerators(curve Curve, label []byte, n int) (xs, ys []*big.Int) {
+	xs, ys = make([]*big.Int, n), make([]*big.Int, n)
+	for i := range xs {
+		xs[i], ys[i] = NUMSGenerator(curve, label, uint32(i))
+	}
+
+	return xs, ys
+}
@@ -0,0 +1,103 @@
+package elliptic_test
+
+import (
+	stdElliptic "crypto/elliptic"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/sammy00/crypto/elliptic"
+)
+
+func TestTryAndIncrement(t *testing.T) {
+	// the generator H of secp256k1-zkp
+	const (
+		Hx = "50929b74c1a04954b78b4b6035e97a5e078a5a0f28ec96d547bfee9ace803ac0"
+		Hy = "31d3c6863973926e049e637cb1b5f40a36dac28af1766968c30c2313f3a38904"
+	)
+
+	curve := elliptic.P256k1()
+	params := curve.Params()
+
+	seed := sha256.Sum256(elliptic.Marshal(curve, params.Gx, params.Gy))
+	x, y := elliptic.TryAndIncrement(curve, seed[:])
+
+	if got := fmt.Sprintf("%064x", x); Hx != got {
+		t.Fatalf("invalid x: got %s, want %s", got, Hx)
+	}
+	if got := fmt.Sprintf("%064x", y); Hy != got {
+		t.Fatalf("invalid y: got %s, want %s", got, Hy)
+	}
+}
+
+func TestNUMSGenerators(t *testing.T) {
+	curves := map[string]elliptic.Curve{
+		"secp256k1": elliptic.P256k1(),
+		"P-521":     elliptic.FromStd(stdElliptic.P521()),
+		"toy":       toyCurve(),
+	}
+
+	for name, curve := range curves {
+		t.Run(name, func(t *testing.T) {
+			const n = 8
+			xs, ys := elliptic.NUMSGenerators(curve, []byte("test"), n)
+
+			seen := make(map[string]bool)
+			for i := range xs {
+				if !elliptic.IsInSubgroup(curve, xs[i], ys[i]) {
+					t.Fatalf("#%d generator should be in the prime-order subgroup", i)
+				}
+
+				key := xs[i].String() + "," + ys[i].String()
+				if seen[key] {
+					t.Fatalf("#%d generator is repeated", i)
+				}
+				seen[key] = true
+
+				// the derivation should be reproducible
+				x, y := elliptic.NUMSGenerator(curve, []byte("test"), uint32(i))
+				if (0 != x.Cmp(xs[i])) || (0 != y.Cmp(ys[i])) {
+					t.Fatalf("#%d generator isn't reproducible", i)
+				}
+			}
+
+			// a different label gives different generators
+			x, y := elliptic.NUMSGenerator(curve, []byte("another test"), 0)
+			if seen[x.String()+","+y.String()] {
+				t.Fatal("generators of different labels should differ")
+			}
+		})
+	}
+}
+
+func TestNUMSGeneratorLayout(t *testing.T) {
+	curve := elliptic.FromStd(stdElliptic.P521())
+	label := []byte("test")
+
+	// P-521 needs 66 bytes, i.e., blocks 0 to 2 with the last truncated
+	var seed []byte
+	for block := byte(0); block < 3; block++ {
+		h := sha256.New()
+		h.Write(label)
+		h.Write([]byte{0, 0, 0, 3, 0, 0, 0, block})
+		seed = h.Sum(seed)
+	}
+	wantX, wantY := elliptic.TryAndIncrement(curve, seed[:66])
+
+	x, y := elliptic.NUMSGenerator(curve, label, 3)
+	if (0 != x.Cmp(wantX)) || (0 != y.Cmp(wantY)) {
+		t.Fatal("the generator should follow the documented seed layout")
+	}
+}
+
+func TestTryAndIncrementOutOfField(t *testing.T) {
+	curve := toyCurve()
+
+	// a seed beyond P is reduced before searching
+	seed := new(big.Int).Add(curve.Params().P, big.NewInt(2))
+	x, y := elliptic.TryAndIncrement(curve, seed.Bytes())
+	if !elliptic.IsInSubgroup(curve, x, y) {
+		t.Fatal("the point should be in the prime-order subgroup")
+	}
+}
@@ -0,0 +1,304 @@
+package elliptic
+
+import (
+	"errors"
+	"math/big"
+)
+
+// secp256k1Curve specialises the KoblitzCurve for secp256k1, whose group
+// operations run over the dedicated field arithmetic in Jacobian coordinates
+// instead of math/big. It is still not constant-time.
+type secp256k1Curve struct {
+	*KoblitzCurve
+}
+
+// jacobianPoint is a point in Jacobian coordinates, where z = 0 denotes the
+// point at the infinity
+type jacobianPoint struct {
+	x, y, z fieldElement
+}
+
+// Add calculates (x1,y1)+(x2,y2) over the curve
+func (curve *secp256k1Curve) Add(x1, y1, x2, y2 *big.Int) (x, y *big.Int) {
+	var p, q jacobianPoint
+	p.fromAffine(x1, y1)
+	q.fromAffine(x2, y2)
+
+	p.add(&p, &q)
+
+	return p.toAffine()
+}
+
+// Double calculates 2*(x,y)
+func (curve *secp256k1Curve) Double(x1, y1 *big.Int) (x, y *big.Int) {
+	var p jacobianPoint
+	p.fromAffine(x1, y1)
+
+	p.double(&p)
+
+	return p.toAffine()
+}
+
+// Inverse returns the inverse of k modulo the group order N, which helps to
+// speed up the ECDSA signing and verification
+func (curve *secp256k1Curve) Inverse(k *big.Int) *big.Int {
+	var s scalar
+	s.SetBig(k)
+
+	scalarInv(&s, &s)
+
+	return s.Big()
+}
+
+// DecompressPoint estimates the Y coordinate for the given X coordinate
+// with the dedicated field arithmetic
+func (curve *secp256k1Curve) DecompressPoint(x *big.Int, yOdd bool) (*big.Int, error) {
+	// y^2 = x^3+7
+	var xx, y2, y fieldElement
+	xx.SetBig(x)
+	feSqr(&y2, &xx)
+	feMul(&y2, &y2, &xx)
+	feAdd(&y2, &y2, &fieldElement{7})
+
+	if !feSqrt(&y, &y2) {
+		return nil, errors.New("x is not on the curve")
+	}
+	if 1 == (y[0] & 1) != yOdd {
+		if y.IsZero() {
+			return nil, errors.New("oddness of y is wrong")
+		}
+		feNeg(&y, &y)
+	}
+
+	return y.Big(), nil
+}
+
+// ScalarBaseMult calculates k*G
+func (curve *secp256k1Curve) ScalarBaseMult(k []byte) (x, y *big.Int) {
+	return curve.ScalarMult(curve.Gx, curve.Gy, k)
+}
+
+// ScalarMult estimates k*(x1,y1)
+func (curve *secp256k1Curve) ScalarMult(x1, y1 *big.Int, k []byte) (x, y *big.Int) {
+	var p, q jacobianPoint
+	p.fromAffine(x1, y1)
+
+	for _, b := range k {
+		for i := 0; i < 8; i++ {
+			q.double(&q)
+			if 0x80 == (b & 0x80) {
+				q.addMixed(&q, &p)
+			}
+			b <<= 1
+		}
+	}
+
+	return q.toAffine()
+}
+
+// fromAffine sets p to the affine point (x,y), where (0,0) is regarded as the
+// point at the infinity
+func (p *jacobianPoint) fromAffine(x, y *big.Int) {
+	p.x.SetBig(x)
+	p.y.SetBig(y)
+	p.z = fieldElement{}
+
+	if (0 != x.Sign()) || (0 != y.Sign()) {
+		p.z[0] = 1
+	}
+}
+
+// toAffine reverses the Jacobian transform. In case of point at the
+// infinity, it returns (0,0).
+func (p *jacobianPoint) toAffine() (x, y *big.Int) {
+	if p.z.IsZero() {
+		return new(big.Int), new(big.Int)
+	}
+
+	var zInv, zInv2, xOut, yOut fieldElement
+	feInv(&zInv, &p.z)
+	feSqr(&zInv2, &zInv)
+
+	// xOut = x/z^2
+	feMul(&xOut, &p.x, &zInv2)
+	// yOut = y/z^3
+	feMul(&zInv2, &zInv2, &zInv)
+	feMul(&yOut, &p.y, &zInv2)
+
+	return xOut.Big(), yOut.Big()
+}
+
+// double sets p = 2*q
+func (p *jacobianPoint) double(q *jacobianPoint) {
+	// see http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#doubling-dbl-2009-l
+	var A, B, C, D, E, F, t fieldElement
+
+	// A = x^2
+	feSqr(&A, &q.x)
+	// B = y^2
+	feSqr(&B, &q.y)
+	// C = B^2
+	feSqr(&C, &B)
+
+	// D = 2*((x+B)^2-A-C)
+	feAdd(&D, &q.x, &B)
+	feSqr(&D, &D)
+	feSub(&D, &D, &A)
+	feSub(&D, &D, &C)
+	feAdd(&D, &D, &D)
+	// E = 3*A
+	feAdd(&E, &A, &A)
+	feAdd(&E, &E, &A)
+	// F = E^2
+	feSqr(&F, &E)
+
+	// zOut = 2*y*z, which goes first as q may alias p
+	feMul(&p.z, &q.y, &q.z)
+	feAdd(&p.z, &p.z, &p.z)
+	// xOut = F-2*D
+	feSub(&p.x, &F, &D)
+	feSub(&p.x, &p.x, &D)
+	// yOut = E*(D-xOut)-8*C
+	feSub(&t, &D, &p.x)
+	feMul(&t, &E, &t)
+	feAdd(&C, &C, &C)
+	feAdd(&C, &C, &C)
+	feAdd(&C, &C, &C)
+	feSub(&p.y, &t, &C)
+}
+
+// add sets p = q1+q2
+func (p *jacobianPoint) add(q1, q2 *jacobianPoint) {
+	// see http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#addition-add-2007-bl
+	if q1.z.IsZero() {
+		*p = *q2
+		return
+	}
+	if q2.z.IsZero() {
+		*p = *q1
+		return
+	}
+
+	var z1z1, z2z2, u1, u2, s1, s2, h, i, j, r, v fieldElement
+
+	// z1z1 = z1^2, z2z2 = z2^2
+	feSqr(&z1z1, &q1.z)
+	feSqr(&z2z2, &q2.z)
+	// u1 = x1*z2z2, u2 = x2*z1z1
+	feMul(&u1, &q1.x, &z2z2)
+	feMul(&u2, &q2.x, &z1z1)
+	// s1 = y1*z2*z2z2, s2 = y2*z1*z1z1
+	feMul(&s1, &q1.y, &q2.z)
+	feMul(&s1, &s1, &z2z2)
+	feMul(&s2, &q2.y, &q1.z)
+	feMul(&s2, &s2, &z1z1)
+
+	// h = u2-u1
+	feSub(&h, &u2, &u1)
+	// r = 2*(s2-s1)
+	feSub(&r, &s2, &s1)
+	if h.IsZero() {
+		if r.IsZero() {
+			// q1 == q2
+			p.double(q1)
+		} else {
+			// q1 == -q2
+			*p = jacobianPoint{}
+		}
+		return
+	}
+	feAdd(&r, &r, &r)
+
+	// i = (2*h)^2
+	feAdd(&i, &h, &h)
+	feSqr(&i, &i)
+	// j = h*i
+	feMul(&j, &h, &i)
+	// v = u1*i
+	feMul(&v, &u1, &i)
+
+	// zOut = ((z1+z2)^2-z1z1-z2z2)*h
+	feAdd(&p.z, &q1.z, &q2.z)
+	feSqr(&p.z, &p.z)
+	feSub(&p.z, &p.z, &z1z1)
+	feSub(&p.z, &p.z, &z2z2)
+	feMul(&p.z, &p.z, &h)
+	// xOut = r^2-j-2*v
+	feSqr(&p.x, &r)
+	feSub(&p.x, &p.x, &j)
+	feSub(&p.x, &p.x, &v)
+	feSub(&p.x, &p.x, &v)
+	// yOut = r*(v-xOut)-2*s1*j
+	feSub(&v, &v, &p.x)
+	feMul(&v, &r, &v)
+	feMul(&s1, &s1, &j)
+	feAdd(&s1, &s1, &s1)
+	feSub(&p.y, &v, &s1)
+}
+
+// addMixed sets p = q1+q2, where q2 must be in affine form, i.e., either
+// with z = 1 or as the point at the infinity
+func (p *jacobianPoint) addMixed(q1, q2 *jacobianPoint) {
+	// see http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#addition-madd-2007-bl
+	if q1.z.IsZero() {
+		*p = *q2
+		return
+	}
+	if q2.z.IsZero() {
+		*p = *q1
+		return
+	}
+
+	var z1z1, u2, s2, h, hh, i, j, r, v fieldElement
+
+	// z1z1 = z1^2
+	feSqr(&z1z1, &q1.z)
+	// u2 = x2*z1z1
+	feMul(&u2, &q2.x, &z1z1)
+	// s2 = y2*z1*z1z1
+	feMul(&s2, &q2.y, &q1.z)
+	feMul(&s2, &s2, &z1z1)
+
+	// h = u2-x1
+	feSub(&h, &u2, &q1.x)
+	// r = 2*(s2-y1)
+	feSub(&r, &s2, &q1.y)
+	if h.IsZero() {
+		if r.IsZero() {
+			p.double(q1)
+		} else {
+			*p = jacobianPoint{}
+		}
+		return
+	}
+	feAdd(&r, &r, &r)
+
+	// hh = h^2
+	feSqr(&hh, &h)
+	// i = 4*hh
+	feAdd(&i, &hh, &hh)
+	feAdd(&i, &i, &i)
+	// j = h*i
+	feMul(&j, &h, &i)
+	// v = x1*i
+	feMul(&v, &q1.x, &i)
+
+	// zOut = (z1+h)^2-z1z1-hh
+	feAdd(&p.z, &q1.z, &h)
+	feSqr(&p.z, &p.z)
+	feSub(&p.z, &p.z, &z1z1)
+	feSub(&p.z, &p.z, &hh)
+	// yOut needs the original y1, which may alias p.y
+	var y1j fieldElement
+	feMul(&y1j, &q1.y, &j)
+	feAdd(&y1j, &y1j, &y1j)
+	// xOut = r^2-j-2*v
+	feSqr(&p.x, &r)
+	feSub(&p.x, &p.x, &j)
+	feSub(&p.x, &p.x, &v)
+	feSub(&p.x, &p.x, &v)
+	// yOut = r*(v-xOut)-2*y1*j
+	feSub(&v, &v, &p.x)
+	feMul(&v, &r, &v)
+	feSub(&p.y, &v, &y1j)
+}
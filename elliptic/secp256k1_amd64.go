@@ -0,0 +1,40 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+package elliptic
+
+// useADX tells if the CPU supports the MULX (BMI2) and ADCX/ADOX (ADX)
+// instructions, in which case the assembly takes the faster path
+var useADX = supportsADX()
+
+// supportsADX checks the CPUID for both of the BMI2 and ADX extensions
+func supportsADX() bool {
+	if maxID, _, _, _ := cpuid(0, 0); maxID < 7 {
+		return false
+	}
+
+	_, ebx, _, _ := cpuid(7, 0)
+	bmi2 := (ebx & (1 << 8)) != 0
+	adx := (ebx & (1 << 19)) != 0
+
+	return bmi2 && adx
+}
+
+// cpuid is implemented in secp256k1_amd64.s.
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+// feMul sets z = x*y mod p. It is implemented in secp256k1_amd64.s.
+//
+//go:noescape
+func feMul(z, x, y *fieldElement)
+
+// feSqr sets z = x*x mod p. It is implemented in secp256k1_amd64.s.
+//
+//go:noescape
+func feSqr(z, x *fieldElement)
+
+// scalarMontMul sets z = x*y*R^{-1} mod N. It is implemented in
+// secp256k1_amd64.s.
+//
+//go:noescape
+func scalarMontMul(z, x, y *scalar)
@@ -0,0 +1,310 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+#include "textflag.h"
+
+// The 512-bit product of the 256-bit operands at SI and DI goes into the
+// registers R8..R15 (least significant first), clobbering AX, BX, CX and DX.
+// MULROW0/MULROW form the schoolbook multiplication with the plain MULQ,
+// while MULXROW0/MULXROW do the same with MULX and two independent carry
+// chains by ADCX/ADOX.
+
+#define MULROW0 \
+	MOVQ (8*0)(SI), CX;\
+	MOVQ (8*0)(DI), AX;\
+	MULQ CX;\
+	MOVQ AX, R8;\
+	MOVQ DX, R9;\
+	MOVQ (8*1)(DI), AX;\
+	MULQ CX;\
+	ADDQ AX, R9;\
+	ADCQ $0, DX;\
+	MOVQ DX, R10;\
+	MOVQ (8*2)(DI), AX;\
+	MULQ CX;\
+	ADDQ AX, R10;\
+	ADCQ $0, DX;\
+	MOVQ DX, R11;\
+	MOVQ (8*3)(DI), AX;\
+	MULQ CX;\
+	ADDQ AX, R11;\
+	ADCQ $0, DX;\
+	MOVQ DX, R12
+
+#define MULROW(off, t0, t1, t2, t3, t4) \
+	MOVQ (off)(SI), CX;\
+	MOVQ (8*0)(DI), AX;\
+	MULQ CX;\
+	ADDQ AX, t0;\
+	ADCQ $0, DX;\
+	MOVQ DX, BX;\
+	MOVQ (8*1)(DI), AX;\
+	MULQ CX;\
+	ADDQ BX, t1;\
+	ADCQ $0, DX;\
+	ADDQ AX, t1;\
+	ADCQ $0, DX;\
+	MOVQ DX, BX;\
+	MOVQ (8*2)(DI), AX;\
+	MULQ CX;\
+	ADDQ BX, t2;\
+	ADCQ $0, DX;\
+	ADDQ AX, t2;\
+	ADCQ $0, DX;\
+	MOVQ DX, BX;\
+	MOVQ (8*3)(DI), AX;\
+	MULQ CX;\
+	ADDQ BX, t3;\
+	ADCQ $0, DX;\
+	ADDQ AX, t3;\
+	ADCQ $0, DX;\
+	MOVQ DX, t4
+
+#define MULXROW0 \
+	MOVQ (8*0)(SI), DX;\
+	MULXQ (8*0)(DI), R8, R9;\
+	MULXQ (8*1)(DI), AX, R10;\
+	ADDQ AX, R9;\
+	MULXQ (8*2)(DI), AX, R11;\
+	ADCQ AX, R10;\
+	MULXQ (8*3)(DI), AX, R12;\
+	ADCQ AX, R11;\
+	ADCQ $0, R12
+
+#define MULXROW(off, t0, t1, t2, t3, t4) \
+	MOVQ (off)(SI), DX;\
+	MOVQ $0, t4;\
+	XORQ CX, CX;\
+	MULXQ (8*0)(DI), AX, BX;\
+	ADCXQ AX, t0;\
+	ADOXQ BX, t1;\
+	MULXQ (8*1)(DI), AX, BX;\
+	ADCXQ AX, t1;\
+	ADOXQ BX, t2;\
+	MULXQ (8*2)(DI), AX, BX;\
+	ADCXQ AX, t2;\
+	ADOXQ BX, t3;\
+	MULXQ (8*3)(DI), AX, BX;\
+	ADCXQ AX, t3;\
+	ADOXQ BX, t4;\
+	ADCXQ CX, t4
+
+#define MUL256 \
+	MULROW0;\
+	MULROW(8*1, R9, R10, R11, R12, R13);\
+	MULROW(8*2, R10, R11, R12, R13, R14);\
+	MULROW(8*3, R11, R12, R13, R14, R15)
+
+#define MULX256 \
+	MULXROW0;\
+	MULXROW(8*1, R9, R10, R11, R12, R13);\
+	MULXROW(8*2, R10, R11, R12, R13, R14);\
+	MULXROW(8*3, R11, R12, R13, R14, R15)
+
+// FEREDUCE reduces R8..R15 modulo p = 2^256-0x1000003d1 into R8..R11, by
+// folding the upper half onto the lower one with 2^256 = 0x1000003d1 (mod p).
+// It clobbers AX, BX, CX, DX and R12..R15.
+#define FEREDUCE \
+	MOVQ $0x1000003d1, CX;\
+	MOVQ R12, AX;\
+	MULQ CX;\
+	ADDQ AX, R8;\
+	ADCQ $0, DX;\
+	MOVQ DX, BX;\
+	MOVQ R13, AX;\
+	MULQ CX;\
+	ADDQ BX, R9;\
+	ADCQ $0, DX;\
+	ADDQ AX, R9;\
+	ADCQ $0, DX;\
+	MOVQ DX, BX;\
+	MOVQ R14, AX;\
+	MULQ CX;\
+	ADDQ BX, R10;\
+	ADCQ $0, DX;\
+	ADDQ AX, R10;\
+	ADCQ $0, DX;\
+	MOVQ DX, BX;\
+	MOVQ R15, AX;\
+	MULQ CX;\
+	ADDQ BX, R11;\
+	ADCQ $0, DX;\
+	ADDQ AX, R11;\
+	ADCQ $0, DX;\
+	MOVQ DX, AX;\
+	MULQ CX;\
+	ADDQ AX, R8;\
+	ADCQ DX, R9;\
+	ADCQ $0, R10;\
+	ADCQ $0, R11;\
+	SBBQ AX, AX;\
+	ANDQ CX, AX;\
+	ADDQ AX, R8;\
+	ADCQ $0, R9;\
+	ADCQ $0, R10;\
+	ADCQ $0, R11;\
+	MOVQ R8, R12;\
+	MOVQ R9, R13;\
+	MOVQ R10, R14;\
+	MOVQ R11, R15;\
+	ADDQ CX, R12;\
+	ADCQ $0, R13;\
+	ADCQ $0, R14;\
+	ADCQ $0, R15;\
+	CMOVQCS R12, R8;\
+	CMOVQCS R13, R9;\
+	CMOVQCS R14, R10;\
+	CMOVQCS R15, R11
+
+// SCREDROUND does one round of the word-by-word Montgomery reduction modulo
+// the group order N, which zeroes t0 and leaves the carry (if any) of t4 in
+// the flags. It clobbers AX, BX, DX and DI.
+#define SCREDROUND(t0, t1, t2, t3, t4) \
+	MOVQ $0x4b0dff665588b13f, AX;\
+	MULQ t0;\
+	MOVQ AX, DI;\
+	MOVQ $0xbfd25e8cd0364141, AX;\
+	MULQ DI;\
+	ADDQ AX, t0;\
+	ADCQ $0, DX;\
+	MOVQ DX, BX;\
+	MOVQ $0xbaaedce6af48a03b, AX;\
+	MULQ DI;\
+	ADDQ BX, t1;\
+	ADCQ $0, DX;\
+	ADDQ AX, t1;\
+	ADCQ $0, DX;\
+	MOVQ DX, BX;\
+	MOVQ $0xfffffffffffffffe, AX;\
+	MULQ DI;\
+	ADDQ BX, t2;\
+	ADCQ $0, DX;\
+	ADDQ AX, t2;\
+	ADCQ $0, DX;\
+	MOVQ DX, BX;\
+	MOVQ $0xffffffffffffffff, AX;\
+	MULQ DI;\
+	ADDQ BX, t3;\
+	ADCQ $0, DX;\
+	ADDQ AX, t3;\
+	ADCQ $0, DX;\
+	ADDQ DX, t4
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func feMul(z, x, y *fieldElement)
+TEXT ·feMul(SB), NOSPLIT, $0-24
+	MOVQ x+8(FP), SI
+	MOVQ y+16(FP), DI
+
+	CMPB ·useADX(SB), $1
+	JEQ  feMulADX
+
+	MUL256
+	JMP feMulReduce
+
+feMulADX:
+	MULX256
+
+feMulReduce:
+	FEREDUCE
+
+	MOVQ z+0(FP), DI
+	MOVQ R8, (8*0)(DI)
+	MOVQ R9, (8*1)(DI)
+	MOVQ R10, (8*2)(DI)
+	MOVQ R11, (8*3)(DI)
+	RET
+
+// func feSqr(z, x *fieldElement)
+TEXT ·feSqr(SB), NOSPLIT, $0-16
+	MOVQ x+8(FP), SI
+	MOVQ SI, DI
+
+	CMPB ·useADX(SB), $1
+	JEQ  feSqrADX
+
+	MUL256
+	JMP feSqrReduce
+
+feSqrADX:
+	MULX256
+
+feSqrReduce:
+	FEREDUCE
+
+	MOVQ z+0(FP), DI
+	MOVQ R8, (8*0)(DI)
+	MOVQ R9, (8*1)(DI)
+	MOVQ R10, (8*2)(DI)
+	MOVQ R11, (8*3)(DI)
+	RET
+
+// func scalarMontMul(z, x, y *scalar)
+TEXT ·scalarMontMul(SB), NOSPLIT, $0-24
+	MOVQ x+8(FP), SI
+	MOVQ y+16(FP), DI
+
+	CMPB ·useADX(SB), $1
+	JEQ  scalarMulADX
+
+	MUL256
+	JMP scalarMulReduce
+
+scalarMulADX:
+	MULX256
+
+scalarMulReduce:
+	// SI collects the carry beyond the 512-bit product
+	XORQ SI, SI
+
+	SCREDROUND(R8, R9, R10, R11, R12)
+	ADCQ $0, R13
+	ADCQ $0, R14
+	ADCQ $0, R15
+	ADCQ $0, SI
+
+	SCREDROUND(R9, R10, R11, R12, R13)
+	ADCQ $0, R14
+	ADCQ $0, R15
+	ADCQ $0, SI
+
+	SCREDROUND(R10, R11, R12, R13, R14)
+	ADCQ $0, R15
+	ADCQ $0, SI
+
+	SCREDROUND(R11, R12, R13, R14, R15)
+	ADCQ $0, SI
+
+	// SI:R15..R12 < 2N, so subtract N iff no borrow occurs
+	MOVQ R12, R8
+	MOVQ R13, R9
+	MOVQ R14, R10
+	MOVQ R15, R11
+	MOVQ $0xbfd25e8cd0364141, AX
+	SUBQ AX, R8
+	MOVQ $0xbaaedce6af48a03b, AX
+	SBBQ AX, R9
+	SBBQ $-2, R10
+	SBBQ $-1, R11
+	SBBQ $0, SI
+	CMOVQCC R8, R12
+	CMOVQCC R9, R13
+	CMOVQCC R10, R14
+	CMOVQCC R11, R15
+
+	MOVQ z+0(FP), DI
+	MOVQ R12, (8*0)(DI)
+	MOVQ R13, (8*1)(DI)
+	MOVQ R14, (8*2)(DI)
+	MOVQ R15, (8*3)(DI)
+	RET
@@ -0,0 +1,96 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+package elliptic
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestADXAgainstMULQ checks both code paths of the assembly agree with
+// each other
+func TestADXAgainstMULQ(t *testing.T) {
+	if !supportsADX() {
+		t.Skip("the CPU supports no MULX/ADX")
+	}
+	defer func(v bool) { useADX = v }(useADX)
+
+	fieldValues := fieldTestValues(t, secp256k1P)
+	scalarValues := fieldTestValues(t, secp256k1N)
+
+	for i := range fieldValues {
+		for j := range fieldValues {
+			var x, y, zADX, zMULQ fieldElement
+			x.SetBig(fieldValues[i])
+			y.SetBig(fieldValues[j])
+
+			useADX = true
+			feMul(&zADX, &x, &y)
+			useADX = false
+			feMul(&zMULQ, &x, &y)
+
+			if !zADX.Equal(&zMULQ) {
+				t.Fatalf("feMul(%x,%x): ADX gives %x, MULQ gives %x",
+					fieldValues[i], fieldValues[j], zADX.Big(), zMULQ.Big())
+			}
+
+			useADX = true
+			feSqr(&zADX, &x)
+			useADX = false
+			feSqr(&zMULQ, &x)
+
+			if !zADX.Equal(&zMULQ) {
+				t.Fatalf("feSqr(%x): ADX gives %x, MULQ gives %x",
+					fieldValues[i], zADX.Big(), zMULQ.Big())
+			}
+
+			var k, l, sADX, sMULQ scalar
+			k = scalar(limbsFromBig(scalarValues[i], secp256k1N))
+			l = scalar(limbsFromBig(scalarValues[j], secp256k1N))
+
+			useADX = true
+			scalarMontMul(&sADX, &k, &l)
+			useADX = false
+			scalarMontMul(&sMULQ, &k, &l)
+
+			if sADX != sMULQ {
+				t.Fatalf("scalarMontMul(%x,%x): ADX gives %x, MULQ gives %x",
+					scalarValues[i], scalarValues[j],
+					bigFromLimbs((*[4]uint64)(&sADX)),
+					bigFromLimbs((*[4]uint64)(&sMULQ)))
+			}
+		}
+	}
+}
+
+func BenchmarkFieldMul(b *testing.B) {
+	var x, y fieldElement
+	x.SetBig(new(big.Int).Sub(secp256k1P, big.NewInt(3)))
+	y.SetBig(new(big.Int).Sub(secp256k1P, big.NewInt(5)))
+
+	b.Run("ADX", func(bb *testing.B) {
+		if !supportsADX() {
+			bb.Skip("the CPU supports no MULX/ADX")
+		}
+		defer func(v bool) { useADX = v }(useADX)
+		useADX = true
+
+		for i := 0; i < bb.N; i++ {
+			feMul(&x, &x, &y)
+		}
+	})
+	b.Run("MULQ", func(bb *testing.B) {
+		defer func(v bool) { useADX = v }(useADX)
+		useADX = false
+
+		for i := 0; i < bb.N; i++ {
+			feMul(&x, &x, &y)
+		}
+	})
+	b.Run("Generic", func(bb *testing.B) {
+		for i := 0; i < bb.N; i++ {
+			feMulGeneric(&x, &x, &y)
+		}
+	})
+}
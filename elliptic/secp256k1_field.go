@@ -0,0 +1,336 @@
+package elliptic
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+
+	"github.com/sammy00/crypto/misc"
+)
+
+// The field arithmetic of secp256k1 works on fully reduced 256-bit integers
+// stored as 4 little-endian 64-bit limbs. Since p = 2^256 - 0x1000003d1, any
+// 512-bit product can be reduced by folding the upper half back onto the
+// lower half with a multiplication by 0x1000003d1.
+//
+// The scalar arithmetic (modulo the group order N) has no such nice shape, so
+// it goes in the Montgomery domain with R = 2^256 instead.
+//
+// feMul, feSqr and scalarMontMul are implemented in assembly for amd64 and
+// fall back to the pure-Go feMulGeneric and scalarMontMulGeneric otherwise.
+
+// feK is 2^256 mod p for secp256k1
+const feK = 0x1000003d1
+
+// fieldElement is an element of GF(p) of secp256k1
+type fieldElement [4]uint64
+
+// scalar is an element of GF(N) of secp256k1, usually in Montgomery form
+type scalar [4]uint64
+
+var (
+	// feP is the field prime of secp256k1
+	feP = fieldElement{0xfffffffefffffc2f, 0xffffffffffffffff,
+		0xffffffffffffffff, 0xffffffffffffffff}
+	// fePMinus2 is the exponent for inversion in GF(p)
+	fePMinus2 = fieldElement{0xfffffffefffffc2d, 0xffffffffffffffff,
+		0xffffffffffffffff, 0xffffffffffffffff}
+	// fePPlus1Over4 is the exponent for square roots in GF(p), as p = 3 mod 4
+	fePPlus1Over4 = fieldElement{0xffffffffbfffff0c, 0xffffffffffffffff,
+		0xffffffffffffffff, 0x3fffffffffffffff}
+
+	// scalarN is the group order of secp256k1
+	scalarN = scalar{0xbfd25e8cd0364141, 0xbaaedce6af48a03b,
+		0xfffffffffffffffe, 0xffffffffffffffff}
+	// scalarNMinus2 is the exponent for inversion in GF(N)
+	scalarNMinus2 = scalar{0xbfd25e8cd036413f, 0xbaaedce6af48a03b,
+		0xfffffffffffffffe, 0xffffffffffffffff}
+	// scalarRR is R^2 mod N, which maps values into the Montgomery domain
+	scalarRR = scalar{0x896cf21467d7d140, 0x741496c20e7cf878,
+		0xe697f5e45bcd07c6, 0x9d671cd581c69bc5}
+	// scalarOne is 1 in the plain domain, which maps values out of the
+	// Montgomery domain
+	scalarOne = scalar{1, 0, 0, 0}
+)
+
+var (
+	// secp256k1P is feP as a big integer
+	secp256k1P = bigFromLimbs((*[4]uint64)(&feP))
+	// secp256k1N is scalarN as a big integer
+	secp256k1N = bigFromLimbs((*[4]uint64)(&scalarN))
+)
+
+// scalarN0Inv is -N^{-1} mod 2^64, used by the Montgomery reduction
+const scalarN0Inv = 0x4b0dff665588b13f
+
+// SetBig sets z to x mod p and returns z
+func (z *fieldElement) SetBig(x *big.Int) *fieldElement {
+	*z = fieldElement(limbsFromBig(x, secp256k1P))
+	return z
+}
+
+// Big returns x as a big integer
+func (x *fieldElement) Big() *big.Int {
+	return bigFromLimbs((*[4]uint64)(x))
+}
+
+// IsZero reports whether x == 0
+func (x *fieldElement) IsZero() bool {
+	return 0 == (x[0] | x[1] | x[2] | x[3])
+}
+
+// Equal reports whether x == y
+func (x *fieldElement) Equal(y *fieldElement) bool {
+	return 0 == ((x[0] ^ y[0]) | (x[1] ^ y[1]) | (x[2] ^ y[2]) | (x[3] ^ y[3]))
+}
+
+// feAdd sets z = x+y mod p
+func feAdd(z, x, y *fieldElement) {
+	var r [4]uint64
+	var c uint64
+
+	r[0], c = bits.Add64(x[0], y[0], 0)
+	r[1], c = bits.Add64(x[1], y[1], c)
+	r[2], c = bits.Add64(x[2], y[2], c)
+	r[3], c = bits.Add64(x[3], y[3], c)
+
+	// a carry out of the top limb is worth 2^256 = feK (mod p), and adding it
+	// can't overflow again since x+y < 2p
+	r[0], c = bits.Add64(r[0], feK&-c, 0)
+	r[1], c = bits.Add64(r[1], 0, c)
+	r[2], c = bits.Add64(r[2], 0, c)
+	r[3], _ = bits.Add64(r[3], 0, c)
+
+	feReduceOnce(z, &r)
+}
+
+// feSub sets z = x-y mod p
+func feSub(z, x, y *fieldElement) {
+	var b uint64
+
+	z[0], b = bits.Sub64(x[0], y[0], 0)
+	z[1], b = bits.Sub64(x[1], y[1], b)
+	z[2], b = bits.Sub64(x[2], y[2], b)
+	z[3], b = bits.Sub64(x[3], y[3], b)
+
+	// on borrow, add p back, i.e., subtract feK modulo 2^256
+	z[0], b = bits.Sub64(z[0], feK&-b, 0)
+	z[1], b = bits.Sub64(z[1], 0, b)
+	z[2], b = bits.Sub64(z[2], 0, b)
+	z[3], _ = bits.Sub64(z[3], 0, b)
+}
+
+// feNeg sets z = -x mod p
+func feNeg(z, x *fieldElement) {
+	feSub(z, new(fieldElement), x)
+}
+
+// feInv sets z = x^{-1} mod p by Fermat's little theorem. The inverse of 0
+// is 0.
+func feInv(z, x *fieldElement) {
+	var r fieldElement
+	r[0] = 1
+
+	for i := 255; i >= 0; i-- {
+		feSqr(&r, &r)
+		if 1 == (fePMinus2[i/64]>>uint(i%64))&1 {
+			feMul(&r, &r, x)
+		}
+	}
+
+	*z = r
+}
+
+// feSqrt sets z to a square root of x, and reports whether x is a square at
+// all. Since p = 3 mod 4, the root is x^((p+1)/4) if any.
+func feSqrt(z, x *fieldElement) bool {
+	var r, rr fieldElement
+	r[0] = 1
+
+	for i := 255; i >= 0; i-- {
+		feSqr(&r, &r)
+		if 1 == (fePPlus1Over4[i/64]>>uint(i%64))&1 {
+			feMul(&r, &r, x)
+		}
+	}
+
+	feSqr(&rr, &r)
+	if !rr.Equal(x) {
+		return false
+	}
+
+	*z = r
+	return true
+}
+
+// feReduceOnce sets z = r mod p for any r < 2^256
+func feReduceOnce(z *fieldElement, r *[4]uint64) {
+	var t [4]uint64
+	var c uint64
+
+	// r-p = r+feK-2^256, so the subtraction is due iff r+feK overflows
+	t[0], c = bits.Add64(r[0], feK, 0)
+	t[1], c = bits.Add64(r[1], 0, c)
+	t[2], c = bits.Add64(r[2], 0, c)
+	t[3], c = bits.Add64(r[3], 0, c)
+
+	mask := -c
+	z[0] = (t[0] & mask) | (r[0] &^ mask)
+	z[1] = (t[1] & mask) | (r[1] &^ mask)
+	z[2] = (t[2] & mask) | (r[2] &^ mask)
+	z[3] = (t[3] & mask) | (r[3] &^ mask)
+}
+
+// feMulGeneric sets z = x*y mod p
+func feMulGeneric(z, x, y *fieldElement) {
+	var t [8]uint64
+	mul256(&t, (*[4]uint64)(x), (*[4]uint64)(y))
+
+	// fold t[4:] onto t[:4] as t[:4] + t[4:]*feK
+	var r [4]uint64
+	var carry, c uint64
+	for i := 0; i < 4; i++ {
+		hi, lo := bits.Mul64(t[i+4], feK)
+		lo, c = bits.Add64(lo, t[i], 0)
+		hi += c
+		lo, c = bits.Add64(lo, carry, 0)
+		hi += c
+
+		r[i], carry = lo, hi
+	}
+
+	// fold the remaining carry, which is at most 34 bits
+	hi, lo := bits.Mul64(carry, feK)
+	r[0], c = bits.Add64(r[0], lo, 0)
+	r[1], c = bits.Add64(r[1], hi, c)
+	r[2], c = bits.Add64(r[2], 0, c)
+	r[3], c = bits.Add64(r[3], 0, c)
+
+	// and the final tiny carry, which can't overflow anymore
+	r[0], c = bits.Add64(r[0], feK&-c, 0)
+	r[1], c = bits.Add64(r[1], 0, c)
+	r[2], c = bits.Add64(r[2], 0, c)
+	r[3], _ = bits.Add64(r[3], 0, c)
+
+	feReduceOnce(z, &r)
+}
+
+// SetBig sets z to x mod N in the Montgomery domain, and returns z
+func (z *scalar) SetBig(x *big.Int) *scalar {
+	*z = scalar(limbsFromBig(x, secp256k1N))
+	scalarMontMul(z, z, &scalarRR)
+	return z
+}
+
+// Big returns x, which is in the Montgomery domain, as a big integer
+func (x *scalar) Big() *big.Int {
+	var r scalar
+	scalarMontMul(&r, x, &scalarOne)
+	return bigFromLimbs((*[4]uint64)(&r))
+}
+
+// scalarInv sets z = x^{-1} mod N by Fermat's little theorem, where both of
+// z and x are in the Montgomery domain
+func scalarInv(z, x *scalar) {
+	r := *x
+	// the top bit of N-2 is set, so start with x itself
+	for i := 254; i >= 0; i-- {
+		scalarMontMul(&r, &r, &r)
+		if 1 == (scalarNMinus2[i/64]>>uint(i%64))&1 {
+			scalarMontMul(&r, &r, x)
+		}
+	}
+
+	*z = r
+}
+
+// scalarMontMulGeneric sets z = x*y*R^{-1} mod N
+func scalarMontMulGeneric(z, x, y *scalar) {
+	var t [8]uint64
+	mul256(&t, (*[4]uint64)(x), (*[4]uint64)(y))
+
+	// Montgomery reduction, word by word
+	var top, c uint64
+	for i := 0; i < 4; i++ {
+		m := t[i] * scalarN0Inv
+
+		var carry uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(m, scalarN[j])
+			lo, c = bits.Add64(lo, t[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+
+			t[i+j], carry = lo, hi
+		}
+
+		for j := i + 4; j < 8; j++ {
+			t[j], carry = bits.Add64(t[j], carry, 0)
+		}
+		top += carry
+	}
+
+	// now t[4:] + top*2^256 < 2N, so subtract N at most once
+	var r [4]uint64
+	var b uint64
+	r[0], b = bits.Sub64(t[4], scalarN[0], 0)
+	r[1], b = bits.Sub64(t[5], scalarN[1], b)
+	r[2], b = bits.Sub64(t[6], scalarN[2], b)
+	r[3], b = bits.Sub64(t[7], scalarN[3], b)
+	_, b = bits.Sub64(top, 0, b)
+
+	mask := -b
+	z[0] = (t[4] & mask) | (r[0] &^ mask)
+	z[1] = (t[5] & mask) | (r[1] &^ mask)
+	z[2] = (t[6] & mask) | (r[2] &^ mask)
+	z[3] = (t[7] & mask) | (r[3] &^ mask)
+}
+
+// mul256 sets t to the 512-bit product x*y
+func mul256(t *[8]uint64, x, y *[4]uint64) {
+	for i := range t {
+		t[i] = 0
+	}
+
+	for i := 0; i < 4; i++ {
+		var carry, c uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(x[i], y[j])
+			lo, c = bits.Add64(lo, t[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+
+			t[i+j], carry = lo, hi
+		}
+		t[i+4] = carry
+	}
+}
+
+// limbsFromBig returns x mod m as 4 little-endian 64-bit limbs
+func limbsFromBig(x, m *big.Int) [4]uint64 {
+	if (x.Sign() < 0) || (x.Cmp(m) >= 0) {
+		x = new(big.Int).Mod(x, m)
+	}
+
+	var buf [32]byte
+	misc.ReverseCopy(buf[:], x.Bytes())
+
+	var limbs [4]uint64
+	for i := range limbs {
+		limbs[i] = binary.BigEndian.Uint64(buf[32-8*(i+1):])
+	}
+
+	return limbs
+}
+
+// bigFromLimbs converts 4 little-endian 64-bit limbs to a big integer
+func bigFromLimbs(limbs *[4]uint64) *big.Int {
+	var buf [32]byte
+	for i, v := range limbs {
+		binary.BigEndian.PutUint64(buf[32-8*(i+1):], v)
+	}
+
+	return new(big.Int).SetBytes(buf[:])
+}
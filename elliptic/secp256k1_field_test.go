@@ -0,0 +1,153 @@
+package elliptic
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// fieldTestValues returns some edge cases along with random values below m
+func fieldTestValues(t *testing.T, m *big.Int) []*big.Int {
+	one := big.NewInt(1)
+	mMinus1 := new(big.Int).Sub(m, one)
+	allOnes := new(big.Int).Lsh(one, 256)
+	allOnes.Sub(allOnes, one)
+
+	values := []*big.Int{
+		new(big.Int), one, big.NewInt(2), mMinus1,
+		new(big.Int).Rsh(m, 1), new(big.Int).Rsh(allOnes, 128),
+		new(big.Int).Lsh(allOnes, 128).Mod(new(big.Int).Lsh(allOnes, 128), m),
+	}
+
+	for i := 0; i < 64; i++ {
+		v, err := rand.Int(rand.Reader, m)
+		if nil != err {
+			t.Fatal(err)
+		}
+		values = append(values, v)
+	}
+
+	return values
+}
+
+func TestFieldMul(t *testing.T) {
+	values := fieldTestValues(t, secp256k1P)
+
+	for _, x := range values {
+		for _, y := range values {
+			expected := new(big.Int).Mul(x, y)
+			expected.Mod(expected, secp256k1P)
+
+			var xx, yy, z, zGeneric fieldElement
+			xx.SetBig(x)
+			yy.SetBig(y)
+
+			feMul(&z, &xx, &yy)
+			feMulGeneric(&zGeneric, &xx, &yy)
+
+			if got := z.Big(); 0 != got.Cmp(expected) {
+				t.Fatalf("invalid %x*%x: got %x, want %x", x, y, got, expected)
+			}
+			if !z.Equal(&zGeneric) {
+				t.Fatalf("invalid generic %x*%x: got %x, want %x", x, y,
+					zGeneric.Big(), expected)
+			}
+		}
+	}
+}
+
+func TestFieldSqr(t *testing.T) {
+	for _, x := range fieldTestValues(t, secp256k1P) {
+		expected := new(big.Int).Mul(x, x)
+		expected.Mod(expected, secp256k1P)
+
+		var xx, z fieldElement
+		xx.SetBig(x)
+		feSqr(&z, &xx)
+
+		if got := z.Big(); 0 != got.Cmp(expected) {
+			t.Fatalf("invalid %x^2: got %x, want %x", x, got, expected)
+		}
+	}
+}
+
+func TestFieldAddSub(t *testing.T) {
+	values := fieldTestValues(t, secp256k1P)
+
+	for _, x := range values {
+		for _, y := range values {
+			var xx, yy, sum, diff fieldElement
+			xx.SetBig(x)
+			yy.SetBig(y)
+
+			feAdd(&sum, &xx, &yy)
+			feSub(&diff, &xx, &yy)
+
+			expected := new(big.Int).Add(x, y)
+			expected.Mod(expected, secp256k1P)
+			if got := sum.Big(); 0 != got.Cmp(expected) {
+				t.Fatalf("invalid %x+%x: got %x, want %x", x, y, got, expected)
+			}
+
+			expected.Sub(x, y)
+			expected.Mod(expected, secp256k1P)
+			if got := diff.Big(); 0 != got.Cmp(expected) {
+				t.Fatalf("invalid %x-%x: got %x, want %x", x, y, got, expected)
+			}
+		}
+	}
+}
+
+func TestFieldInv(t *testing.T) {
+	for _, x := range fieldTestValues(t, secp256k1P)[1:] {
+		var xx, z fieldElement
+		xx.SetBig(x)
+		feInv(&z, &xx)
+
+		expected := new(big.Int).ModInverse(x, secp256k1P)
+		if got := z.Big(); 0 != got.Cmp(expected) {
+			t.Fatalf("invalid %x^{-1}: got %x, want %x", x, got, expected)
+		}
+	}
+}
+
+func TestScalarMontMul(t *testing.T) {
+	values := fieldTestValues(t, secp256k1N)
+	// R^{-1} mod N
+	rInv := new(big.Int).Lsh(big.NewInt(1), 256)
+	rInv.ModInverse(rInv, secp256k1N)
+
+	for _, x := range values {
+		for _, y := range values {
+			expected := new(big.Int).Mul(x, y)
+			expected.Mul(expected, rInv)
+			expected.Mod(expected, secp256k1N)
+
+			xx := scalar(limbsFromBig(x, secp256k1N))
+			yy := scalar(limbsFromBig(y, secp256k1N))
+
+			var z, zGeneric scalar
+			scalarMontMul(&z, &xx, &yy)
+			scalarMontMulGeneric(&zGeneric, &xx, &yy)
+
+			if got := bigFromLimbs((*[4]uint64)(&z)); 0 != got.Cmp(expected) {
+				t.Fatalf("invalid %x*%x/R: got %x, want %x", x, y, got, expected)
+			}
+			if z != zGeneric {
+				t.Fatalf("invalid generic %x*%x/R: got %x, want %x", x, y,
+					bigFromLimbs((*[4]uint64)(&zGeneric)), expected)
+			}
+		}
+	}
+}
+
+func TestScalarInverse(t *testing.T) {
+	curve := P256k1().(*secp256k1Curve)
+
+	for _, k := range fieldTestValues(t, secp256k1N)[1:] {
+		expected := new(big.Int).ModInverse(k, secp256k1N)
+		if got := curve.Inverse(k); 0 != got.Cmp(expected) {
+			t.Fatalf("invalid %x^{-1}: got %x, want %x", k, got, expected)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+//go:build !amd64 || purego
+// +build !amd64 purego
+
+package elliptic
+
+// feMul sets z = x*y mod p
+func feMul(z, x, y *fieldElement) {
+	feMulGeneric(z, x, y)
+}
+
+// feSqr sets z = x*x mod p
+func feSqr(z, x *fieldElement) {
+	feMulGeneric(z, x, x)
+}
+
+// scalarMontMul sets z = x*y*R^{-1} mod N
+func scalarMontMul(z, x, y *scalar) {
+	scalarMontMulGeneric(z, x, y)
+}
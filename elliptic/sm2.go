@@ -0,0 +1,42 @@
+package elliptic
+
+// References:
+//   [GB/T 32918.5]: Information security technology -- Public key
+//     cryptographic algorithm SM2 based on elliptic curves -- Part 5:
+//     Parameter definition
+//   [draft-shen-sm2-ecdsa]: SM2 Digital Signature Algorithm,
+//     https://datatracker.ietf.org/doc/html/draft-shen-sm2-ecdsa-02
+
+import (
+	stdElliptic "crypto/elliptic"
+	"math/big"
+	"sync"
+)
+
+var (
+	// sm2InitOncer serves for one-time-only initialization of sm2p256v1
+	sm2InitOncer sync.Once
+	// sm2p256v1 is the unexported curve which can be captured by SM2P256()
+	sm2p256v1 Curve
+)
+
+// SM2P256 returns the handle of sm2p256v1 recommended by [GB/T 32918.5],
+// whose curve equation is y^2 = x^3 - 3x + b as is for the NIST curves. The
+// group operations are the generic ones of the standard library, which are
+// not constant-time.
+func SM2P256() Curve {
+	sm2InitOncer.Do(initSM2P256)
+	return sm2p256v1
+}
+
+func initSM2P256() {
+	params := &stdElliptic.CurveParams{Name: "sm2p256v1"}
+	params.P, _ = new(big.Int).SetString("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF00000000FFFFFFFFFFFFFFFF", 16)
+	params.N, _ = new(big.Int).SetString("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFF7203DF6B21C6052B53BBF40939D54123", 16)
+	params.B, _ = new(big.Int).SetString("28E9FA9E9D9F5E344D5A9E4BCF6509A7F39789F515AB8F92DDBCBD414D940E93", 16)
+	params.Gx, _ = new(big.Int).SetString("32C4AE2C1F1981195F9904466A39C9948FE30BBFF2660BE1715A4589334C74C7", 16)
+	params.Gy, _ = new(big.Int).SetString("BC3736A2F4F6779C59BDCEE36B692153D0A9877CC62A474002DF32E52139F0A0", 16)
+	params.BitSize = 256
+
+	sm2p256v1 = FromStd(params)
+}
@@ -0,0 +1,35 @@
+package elliptic_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/sammy00/crypto/elliptic"
+)
+
+func TestSM2P256(t *testing.T) {
+	curve := elliptic.SM2P256()
+	params := curve.Params()
+
+	if !curve.IsOnCurve(params.Gx, params.Gy) {
+		t.Fatal("the base point should be on curve")
+	}
+
+	// N*G should be the point at infinity
+	if x, y := curve.ScalarBaseMult(params.N.Bytes()); (0 != x.Sign()) || (0 != y.Sign()) {
+		t.Fatalf("N*G should be the infinity: got (%x,%x)", x, y)
+	}
+
+	for i := 0; i < 8; i++ {
+		_, x, y, err := elliptic.GenerateKey(curve, rand.Reader)
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		data := elliptic.MarshalCompressed(curve, x, y)
+		xRec, yRec := elliptic.UnmarshalCompressed(curve, data)
+		if (nil == xRec) || (0 != xRec.Cmp(x)) || (0 != yRec.Cmp(y)) {
+			t.Fatalf("invalid point: got (%x,%x), want (%x,%x)", xRec, yRec, x, y)
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package elliptic
+
+// References:
+//   [hyperelliptic.org]
+//		 http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian.html
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/sammy00/crypto/misc"
+)
+
+// WeierstrassCurve embeds the parameters of a short Weierstrass curve
+// y^2 = x^3 + ax + b with an arbitrary a, and provides a generic,
+// non-constant time implementation of Curve in Jacobian coordinates. It
+// serves for the curves fitting neither KoblitzCurve (a = 0) nor the ones of
+// the standard library (a = -3), such as the GOST ones.
+type WeierstrassCurve struct {
+	*CurveParams
+	A *big.Int // the coefficient of x in the curve equation
+}
+
+// Add calculates (x1,y1)+(x2,y2) over the curve
+func (curve *WeierstrassCurve) Add(x1, y1, x2, y2 *big.Int) (x, y *big.Int) {
+	z1 := zForAffine(x1, y1)
+	z2 := zForAffine(x2, y2)
+
+	return curve.affineFromJacobian(curve.addJacobian(x1, y1, z1, x2, y2, z2))
+}
+
+// DecompressPoint estimates the Y coordinate for the given X coordinate
+func (curve *WeierstrassCurve) DecompressPoint(x *big.Int, yOdd bool) (*big.Int, error) {
+	params := curve.Params()
+
+	// Y = +-sqrt(x^3+ax+b)
+	x3 := curve.rhs(x)
+
+	y := new(big.Int).ModSqrt(x3, params.P)
+	if nil == y {
+		return nil, errors.New("x is not on the curve")
+	}
+
+	if misc.IsOdd(y) != yOdd {
+		y.Sub(params.P, y)
+	}
+	if misc.IsOdd(y) != yOdd {
+		return nil, errors.New("oddness of y is wrong")
+	}
+
+	return y, nil
+}
+
+// Double calculates 2*(x,y)
+func (curve *WeierstrassCurve) Double(x, y *big.Int) (xOut, yOut *big.Int) {
+	z := zForAffine(x, y)
+	return curve.affineFromJacobian(curve.doubleJacobian(x, y, z))
+}
+
+// IsOnCurve checks if the given point (x,y) is on the curve
+func (curve *WeierstrassCurve) IsOnCurve(x, y *big.Int) bool {
+	// y^2 = x^3 + ax + b
+	y2 := new(big.Int).Mul(y, y)
+	y2.Mod(y2, curve.P)
+
+	return 0 == curve.rhs(x).Cmp(y2)
+}
+
+// Params returns the parameters specification for this curve
+func (curve *WeierstrassCurve) Params() *CurveParams {
+	return curve.CurveParams
+}
+
+// ScalarBaseMult calculates k*G
+func (curve *WeierstrassCurve) ScalarBaseMult(k []byte) (x, y *big.Int) {
+	return curve.ScalarMult(curve.Gx, curve.Gy, k)
+}
+
+// ScalarMult estimates k*(x1,y1)
+func (curve *WeierstrassCurve) ScalarMult(x1, y1 *big.Int, k []byte) (x, y *big.Int) {
+	z1 := zForAffine(x1, y1)
+	xx, yy, zz := new(big.Int), new(big.Int), new(big.Int)
+
+	for _, b := range k {
+		for i := 0; i < 8; i++ {
+			xx, yy, zz = curve.doubleJacobian(xx, yy, zz)
+			if 0x80 == (b & 0x80) {
+				xx, yy, zz = curve.addJacobian(x1, y1, z1, xx, yy, zz)
+			}
+			b <<= 1
+		}
+	}
+
+	return curve.affineFromJacobian(xx, yy, zz)
+}
+
+// rhs calculates x^3+ax+b mod P
+func (curve *WeierstrassCurve) rhs(x *big.Int) *big.Int {
+	out := new(big.Int).Mul(x, x)
+	out.Add(out, curve.A)
+	out.Mul(out, x)
+	out.Add(out, curve.B)
+
+	return out.Mod(out, curve.P)
+}
+
+// addJacobian estimate the sum of two Jacobian point (x1,y1,z1) and (x2,y2,z2)
+func (curve *WeierstrassCurve) addJacobian(x1, y1, z1, x2, y2, z2 *big.Int) (x, y, z *big.Int) {
+	// http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian.html#addition-add-2007-bl
+	x, y, z = new(big.Int), new(big.Int), new(big.Int)
+	// P + O = P
+	if 0 == z1.Sign() {
+		x.Set(x2)
+		y.Set(y2)
+		z.Set(z2)
+		return
+	}
+	if 0 == z2.Sign() {
+		x.Set(x1)
+		y.Set(y1)
+		z.Set(z1)
+		return
+	}
+
+	// z1^2
+	z12 := new(big.Int).Mul(z1, z1)
+	z12.Mod(z12, curve.P)
+	// z2^2
+	z22 := new(big.Int).Mul(z2, z2)
+	z22.Mod(z22, curve.P)
+
+	// u1 = x1*z2^2
+	u1 := new(big.Int).Mul(x1, z22)
+	u1.Mod(u1, curve.P)
+	// u2 = x2*z1^2
+	u2 := new(big.Int).Mul(x2, z12)
+	u2.Mod(u2, curve.P)
+	// s1 = y1*z2^3
+	s1 := new(big.Int).Mul(y1, z22)
+	s1.Mul(s1, z2)
+	s1.Mod(s1, curve.P)
+	// s2 = y2*z1^3
+	s2 := new(big.Int).Mul(y2, z12)
+	s2.Mul(s2, z1)
+	s2.Mod(s2, curve.P)
+
+	// h = u2-u1
+	h := new(big.Int).Sub(u2, u1)
+	if -1 == h.Sign() {
+		h.Add(h, curve.P)
+	}
+	if 0 == h.Sign() {
+		if s1.Cmp(s2) == 0 {
+			// (x1,y1,z1) == (x2,y2,z2)
+			return curve.doubleJacobian(x1, y1, z1)
+		}
+		// (x1,y1,z1) == -(x2,y2,z2), z = 0 follows as the point at infinity
+	}
+	// i = (2*H)^2
+	i := new(big.Int).Lsh(h, 1)
+	i.Mul(i, i)
+	i.Mod(i, curve.P)
+	// j = h*i
+	j := new(big.Int).Mul(h, i)
+	j.Mod(j, curve.P)
+	// r = 2*(s2-s1)
+	r := new(big.Int).Sub(s2, s1)
+	if -1 == r.Sign() {
+		r.Add(r, curve.P)
+	}
+	r.Lsh(r, 1)
+	// v = u1*i
+	v := new(big.Int).Mul(u1, i)
+
+	// x = r^2-j-2*v
+	x.Mul(r, r)
+	x.Sub(x, j)
+	x.Sub(x, v)
+	x.Sub(x, v)
+	x.Mod(x, curve.P)
+	// y = r*(v-x3)-2*s1*j
+	v.Sub(v, x)
+	s1.Mul(s1, j)
+	s1.Lsh(s1, 1)
+	y.Mul(r, v)
+	y.Sub(y, s1)
+	y.Mod(y, curve.P)
+	// z = ((z1+z2)^2-z1^2-z2^2)*h
+	z.Add(z1, z2)
+	z.Mul(z, z)
+	z.Sub(z, z12)
+	z.Sub(z, z22)
+	z.Mul(z, h)
+	z.Mod(z, curve.P)
+
+	return
+}
+
+// affineFromJacobian reverses the Jacobian transform. In case of point at the
+// infinity, it returns (0,0).
+func (curve *WeierstrassCurve) affineFromJacobian(x, y, z *big.Int) (xOut, yOut *big.Int) {
+	xOut, yOut = new(big.Int), new(big.Int)
+	if 0 == z.Sign() {
+		return
+	}
+
+	zInv := new(big.Int).ModInverse(z, curve.P)
+	zInv2 := new(big.Int).Mul(zInv, zInv)
+
+	// xOut = x/z^2
+	xOut.Mul(x, zInv2)
+	xOut.Mod(xOut, curve.P)
+	// yOut = y/z^3
+	zInv2.Mul(zInv2, zInv)
+	yOut.Mul(y, zInv2)
+	yOut.Mod(yOut, curve.P)
+
+	return xOut, yOut
+}
+
+// doubleJacobian takes a point in Jacobian coordinates, (x, y, z), and
+// returns its double, also in Jacobian form.
+func (curve *WeierstrassCurve) doubleJacobian(x, y, z *big.Int) (xOut, yOut, zOut *big.Int) {
+	// http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian.html#doubling-dbl-2007-bl
+	xOut, yOut, zOut = new(big.Int), new(big.Int), new(big.Int)
+	// 2*O = O, and 2*(x,0) = O for points of order 2
+	if (0 == z.Sign()) || (0 == y.Sign()) {
+		return
+	}
+
+	// xx = x^2
+	xx := new(big.Int).Mul(x, x)
+	xx.Mod(xx, curve.P)
+	// yy = y^2
+	yy := new(big.Int).Mul(y, y)
+	yy.Mod(yy, curve.P)
+	// yyyy = yy^2
+	yyyy := new(big.Int).Mul(yy, yy)
+	yyyy.Mod(yyyy, curve.P)
+	// zz = z^2
+	zz := new(big.Int).Mul(z, z)
+	zz.Mod(zz, curve.P)
+
+	// s = 2*((x+yy)^2-xx-yyyy)
+	s := new(big.Int).Add(x, yy)
+	s.Mul(s, s)
+	s.Sub(s, xx)
+	s.Sub(s, yyyy)
+	s.Lsh(s, 1)
+	s.Mod(s, curve.P)
+	// m = 3*xx+a*zz^2
+	m := new(big.Int).Mul(zz, zz)
+	m.Mul(m, curve.A)
+	m.Add(m, xx)
+	m.Add(m, xx)
+	m.Add(m, xx)
+	m.Mod(m, curve.P)
+
+	// x = m^2-2*s
+	xOut.Mul(m, m)
+	xOut.Sub(xOut, s)
+	xOut.Sub(xOut, s)
+	xOut.Mod(xOut, curve.P)
+	// y = m*(s-x)-8*yyyy
+	yOut.Sub(s, xOut)
+	yOut.Mul(yOut, m)
+	yOut.Sub(yOut, yyyy.Lsh(yyyy, 3))
+	yOut.Mod(yOut, curve.P)
+	// z = (y+z)^2-yy-zz
+	zOut.Add(y, z)
+	zOut.Mul(zOut, zOut)
+	zOut.Sub(zOut, yy)
+	zOut.Sub(zOut, zz)
+	zOut.Mod(zOut, curve.P)
+
+	return
+}
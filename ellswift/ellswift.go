@@ -0,0 +1,337 @@
+// Package ellswift implements the ElligatorSwift encoding of secp256k1 public
+// keys and the x-only ECDH built upon it, as is specified by BIP324 for the
+// v2 P2P transport of bitcoin.
+//
+// An ElligatorSwift encoding is 64 bytes (u,t) indistinguishable from
+// uniformly random data, which decodes to the x coordinate of a point on the
+// curve. Since only x coordinates are encoded, the parity of y is lost.
+package ellswift
+
+// References:
+//   [BIP324]: Version 2 P2P Encrypted Transport Protocol
+//     https://github.com/bitcoin/bips/blob/master/bip-0324.mediawiki
+//   [SwiftEC]: SwiftEC: Shallue–van de Woestijne Indifferentiable Function
+//     To Elliptic Curves, https://eprint.iacr.org/2022/759
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/misc"
+)
+
+// EncodedLen is the length in bytes of an ElligatorSwift encoding
+const EncodedLen = 64
+
+// ecdhTag is the tag for hashing the shared secret of the BIP324 ECDH
+const ecdhTag = "bip324_ellswift_xonly_ecdh"
+
+var (
+	// ErrCurveMismatch is returned if the key is not on secp256k1
+	ErrCurveMismatch = errors.New("ellswift: the key is not on secp256k1")
+	// ErrInvalidPoint is returned if the shared point is the point at infinity
+	ErrInvalidPoint = errors.New("ellswift: invalid shared point")
+)
+
+var (
+	// p is the order of the underlying field of secp256k1
+	p = elliptic.P256k1().Params().P
+	// b is the constant of the curve equation of secp256k1
+	b = elliptic.P256k1().Params().B
+	// c is sqrt(-3) mod p
+	c = new(big.Int).ModSqrt(new(big.Int).Sub(p, big.NewInt(3)), p)
+	// halfOneMinusC is (1-c)/2 mod p
+	halfOneMinusC = halve(new(big.Int).Sub(big.NewInt(1), c))
+	// halfOnePlusC is (1+c)/2 mod p
+	halfOnePlusC = halve(new(big.Int).Add(big.NewInt(1), c))
+)
+
+// Encode encodes the x coordinate of the given public key on secp256k1 into
+// 64 uniformly random bytes, drawing the randomness from rand.
+func Encode(rand io.Reader, pub *ecdsa.PublicKey) ([EncodedLen]byte, error) {
+	var out [EncodedLen]byte
+	if pub.Curve != elliptic.P256k1() {
+		return out, ErrCurveMismatch
+	}
+
+	// 32 bytes for u plus 1 byte for the case
+	buf := make([]byte, 33)
+	for {
+		if _, err := io.ReadFull(rand, buf); nil != err {
+			return out, err
+		}
+
+		u := new(big.Int).SetBytes(buf[:32])
+		if (0 == u.Sign()) || (u.Cmp(p) >= 0) {
+			continue
+		}
+
+		if t := XSwiftECInv(u, pub.X, int(buf[32]&0x07)); nil != t {
+			misc.ReverseCopy(out[:32], u.Bytes())
+			misc.ReverseCopy(out[32:], t.Bytes())
+			return out, nil
+		}
+	}
+}
+
+// Decode decodes the ElligatorSwift encoding into the public key on secp256k1
+// with the decoded x coordinate and an even y. Every 64-byte sequence is a
+// valid encoding.
+func Decode(data [EncodedLen]byte) *ecdsa.PublicKey {
+	u := new(big.Int).SetBytes(data[:32])
+	u.Mod(u, p)
+	t := new(big.Int).SetBytes(data[32:])
+	t.Mod(t, p)
+
+	x := XSwiftEC(u, t)
+	// XSwiftEC always returns a valid x
+	y, _ := elliptic.P256k1().DecompressPoint(x, false)
+
+	return &ecdsa.PublicKey{Curve: elliptic.P256k1(), X: x, Y: y}
+}
+
+// Create generates a private key along with the ElligatorSwift encoding of
+// its public key, both of which draws randomness from rand.
+func Create(rand io.Reader) (*ecdsa.PrivateKey, [EncodedLen]byte, error) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256k1(), rand)
+	if nil != err {
+		return nil, [EncodedLen]byte{}, err
+	}
+
+	encoded, err := Encode(rand, &priv.PublicKey)
+	if nil != err {
+		return nil, [EncodedLen]byte{}, err
+	}
+
+	return priv, encoded, nil
+}
+
+// ECDHXOnly decodes the ElligatorSwift-encoded public key of the
+// counter-party, and returns the x coordinate of the point multiplied with
+// the private key.
+func ECDHXOnly(theirs [EncodedLen]byte, priv *ecdsa.PrivateKey) ([32]byte, error) {
+	var out [32]byte
+	if priv.Curve != elliptic.P256k1() {
+		return out, ErrCurveMismatch
+	}
+
+	pub := Decode(theirs)
+	if !elliptic.IsInSubgroup(pub.Curve, pub.X, pub.Y) {
+		return out, ErrInvalidPoint
+	}
+
+	x, y := pub.Curve.ScalarMult(pub.X, pub.Y, priv.D.Bytes())
+	if (0 == x.Sign()) && (0 == y.Sign()) {
+		return out, ErrInvalidPoint
+	}
+	misc.ReverseCopy(out[:], x.Bytes())
+
+	return out, nil
+}
+
+// V2ECDH computes the shared secret of the BIP324 handshake, which is the
+// tagged hash of both ElligatorSwift encodings, with the one of the
+// initiator going first, and the x-only ECDH result.
+func V2ECDH(priv *ecdsa.PrivateKey, theirs, ours [EncodedLen]byte,
+	initiating bool) ([32]byte, error) {
+	x, err := ECDHXOnly(theirs, priv)
+	if nil != err {
+		return [32]byte{}, err
+	}
+
+	if initiating {
+		return taggedHash(ecdhTag, ours[:], theirs[:], x[:]), nil
+	}
+
+	return taggedHash(ecdhTag, theirs[:], ours[:], x[:]), nil
+}
+
+// XSwiftEC maps the field elements (u,t) to the x coordinate of a point on
+// secp256k1. Both of u and t must be in [0,p).
+func XSwiftEC(u, t *big.Int) *big.Int {
+	u, t = new(big.Int).Set(u), new(big.Int).Set(t)
+	if 0 == u.Sign() {
+		u.SetInt64(1)
+	}
+	if 0 == t.Sign() {
+		t.SetInt64(1)
+	}
+
+	// g(u) = u^3+b
+	gu := new(big.Int).Mul(u, u)
+	gu.Mul(gu, u)
+	gu.Add(gu, b)
+	gu.Mod(gu, p)
+
+	// t = 2t if g(u)+t^2 = 0
+	t2 := new(big.Int).Mul(t, t)
+	if 0 == mod(t2.Add(gu, t2)).Sign() {
+		t.Lsh(t, 1)
+		t.Mod(t, p)
+	}
+	t2.Mul(t, t)
+
+	// X = (g(u)-t^2)/2t
+	X := new(big.Int).Sub(gu, t2)
+	X.Mul(X, inverse(new(big.Int).Lsh(t, 1)))
+	X.Mod(X, p)
+
+	// Y = (X+t)/(c*u)
+	Y := new(big.Int).Add(X, t)
+	Y.Mul(Y, inverse(new(big.Int).Mul(c, u)))
+	Y.Mod(Y, p)
+
+	// x = u+4Y^2
+	x := new(big.Int).Mul(Y, Y)
+	x.Lsh(x, 2)
+	x.Add(x, u)
+	x.Mod(x, p)
+	if isValidX(x) {
+		return x
+	}
+
+	// x = (-X/Y-u)/2
+	XOverY := new(big.Int).Mul(X, inverse(Y))
+	x.Add(XOverY, u)
+	x.Neg(x)
+	x = halve(x)
+	if isValidX(x) {
+		return x
+	}
+
+	// x = (X/Y-u)/2, which must be valid now
+	x.Sub(XOverY, u)
+	return halve(x)
+}
+
+// XSwiftECInv finds t such that XSwiftEC(u,t) = x, where x must be a valid
+// x coordinate on secp256k1 and u must be in (0,p). It returns nil if no such
+// t exists for the given case in [0,8).
+func XSwiftECInv(u, x *big.Int, c int) *big.Int {
+	var s, v *big.Int
+
+	// u^3+b
+	gu := new(big.Int).Mul(u, u)
+	gu.Mul(gu, u)
+	gu.Add(gu, b)
+
+	if 0 == (c & 2) {
+		// return nil if -x-u is a valid x coordinate
+		if isValidX(mod(new(big.Int).Neg(new(big.Int).Add(x, u)))) {
+			return nil
+		}
+
+		// v = x, s = -(u^3+b)/(u^2+uv+v^2)
+		v = new(big.Int).Set(x)
+
+		denominator := new(big.Int).Mul(u, u)
+		denominator.Add(denominator, new(big.Int).Mul(u, v))
+		denominator.Add(denominator, new(big.Int).Mul(v, v))
+
+		s = new(big.Int).Neg(gu)
+		s.Mul(s, inverse(mod(denominator)))
+		s.Mod(s, p)
+	} else {
+		// s = x-u
+		s = mod(new(big.Int).Sub(x, u))
+		if 0 == s.Sign() {
+			return nil
+		}
+
+		// r = sqrt(-s(4(u^3+b)+3u^2s))
+		r := new(big.Int).Mul(u, u)
+		r.Mul(r, s)
+		r.Mul(r, big.NewInt(3))
+		r.Add(r, new(big.Int).Lsh(gu, 2))
+		r.Mul(r, s)
+		r.Neg(r)
+		if r = sqrt(mod(r)); nil == r {
+			return nil
+		}
+		if (1 == (c & 1)) && (0 == r.Sign()) {
+			return nil
+		}
+
+		// v = (r/s-u)/2
+		v = new(big.Int).Mul(r, inverse(s))
+		v.Sub(v, u)
+		v = halve(v)
+	}
+
+	w := sqrt(s)
+	if nil == w {
+		return nil
+	}
+
+	// t = w*(u*(1-c)/2+v) or w*(u*(1+c)/2+v), negated for case 0 and 5
+	t := new(big.Int).Set(u)
+	if 0 == (c & 1) {
+		t.Mul(t, halfOneMinusC)
+	} else {
+		t.Mul(t, halfOnePlusC)
+	}
+	t.Add(t, v)
+	t.Mul(t, w)
+	if (0 == (c & 5)) || (5 == (c & 5)) {
+		t.Neg(t)
+	}
+
+	return mod(t)
+}
+
+// isValidX checks if x^3+b is a square, i.e., whether x is the x coordinate
+// of some point on secp256k1
+func isValidX(x *big.Int) bool {
+	gx := new(big.Int).Mul(x, x)
+	gx.Mul(gx, x)
+	gx.Add(gx, b)
+
+	return -1 != big.Jacobi(gx.Mod(gx, p), p)
+}
+
+// sqrt returns the square root of x mod p, or nil if there is none. For
+// consistency with BIP324, it is always x^((p+1)/4) as p = 3 mod 4.
+func sqrt(x *big.Int) *big.Int {
+	return new(big.Int).ModSqrt(x, p)
+}
+
+// inverse returns x^{-1} mod p
+func inverse(x *big.Int) *big.Int {
+	return new(big.Int).ModInverse(x, p)
+}
+
+// halve returns x/2 mod p
+func halve(x *big.Int) *big.Int {
+	x = mod(x)
+	if misc.IsOdd(x) {
+		x.Add(x, p)
+	}
+
+	return x.Rsh(x, 1)
+}
+
+// mod returns x mod p, which overwrites x
+func mod(x *big.Int) *big.Int {
+	return x.Mod(x, p)
+}
+
+// taggedHash returns SHA256(SHA256(tag)||SHA256(tag)||msgs...)
+func taggedHash(tag string, msgs ...[]byte) [32]byte {
+	tagHash := sha256.Sum256([]byte(tag))
+
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, msg := range msgs {
+		h.Write(msg)
+	}
+
+	var out [32]byte
+	h.Sum(out[:0])
+
+	return out
+}
@@ -0,0 +1,145 @@
+package ellswift_test
+
+import (
+	"bytes"
+	stdElliptic "crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/ellswift"
+)
+
+func TestXSwiftEC(t *testing.T) {
+	for i, c := range xswiftecTestVec {
+		var data [ellswift.EncodedLen]byte
+		hex.Decode(data[:], []byte(c.ellswift))
+
+		pub := ellswift.Decode(data)
+		if got := hex.EncodeToString(pub.X.Bytes()); got != trimZeros(c.x) {
+			t.Fatalf("#%d invalid x: got %s, want %s", i, got, c.x)
+		}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			t.Fatalf("#%d the decoded point should be on curve", i)
+		}
+	}
+}
+
+func TestXSwiftECInv(t *testing.T) {
+	for i, c := range xswiftecInvTestVec {
+		u, _ := new(big.Int).SetString(c.u, 16)
+		x, _ := new(big.Int).SetString(c.x, 16)
+
+		for j, expected := range c.cases {
+			tt := ellswift.XSwiftECInv(u, x, j)
+			if "" == expected {
+				if nil != tt {
+					t.Fatalf("#%d case %d: got %x, want none", i, j, tt)
+				}
+				continue
+			}
+
+			if nil == tt {
+				t.Fatalf("#%d case %d: got none, want %s", i, j, expected)
+			}
+			if got := hex.EncodeToString(tt.Bytes()); got != trimZeros(expected) {
+				t.Fatalf("#%d case %d: got %s, want %s", i, j, got, expected)
+			}
+
+			if got := ellswift.XSwiftEC(u, tt); 0 != got.Cmp(x) {
+				t.Fatalf("#%d case %d: XSwiftEC gives %x, want %x", i, j, got, x)
+			}
+		}
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	for i := 0; i < 16; i++ {
+		priv, encoded, err := ellswift.Create(rand.Reader)
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		pub := ellswift.Decode(encoded)
+		if 0 != pub.X.Cmp(priv.X) {
+			t.Fatalf("invalid x: got %x, want %x", pub.X, priv.X)
+		}
+	}
+
+	priv, err := ecdsa.GenerateKey(elliptic.FromStd(stdElliptic.P256()), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if _, err := ellswift.Encode(rand.Reader, &priv.PublicKey); ellswift.ErrCurveMismatch != err {
+		t.Fatalf("invalid error: got %v, want %v", err, ellswift.ErrCurveMismatch)
+	}
+}
+
+func TestV2ECDH(t *testing.T) {
+	for i, c := range v2ECDHTestVec {
+		D, _ := new(big.Int).SetString(c.priv, 16)
+		priv := &ecdsa.PrivateKey{D: D}
+		priv.Curve = elliptic.P256k1()
+		priv.X, priv.Y = priv.Curve.ScalarBaseMult(D.Bytes())
+
+		var ours, theirs [ellswift.EncodedLen]byte
+		hex.Decode(ours[:], []byte(c.ellswiftOurs))
+		hex.Decode(theirs[:], []byte(c.ellswiftTheirs))
+
+		if pub := ellswift.Decode(ours); 0 != pub.X.Cmp(priv.X) {
+			t.Fatalf("#%d our encoding should decode to our public key", i)
+		}
+
+		x, err := ellswift.ECDHXOnly(theirs, priv)
+		if nil != err {
+			t.Fatalf("#%d unexpected error: %v", i, err)
+		}
+		if got := hex.EncodeToString(x[:]); got != c.sharedX {
+			t.Fatalf("#%d invalid shared x: got %s, want %s", i, got, c.sharedX)
+		}
+
+		secret, err := ellswift.V2ECDH(priv, theirs, ours, c.initiating)
+		if nil != err {
+			t.Fatalf("#%d unexpected error: %v", i, err)
+		}
+		if got := hex.EncodeToString(secret[:]); got != c.sharedSecret {
+			t.Fatalf("#%d invalid shared secret: got %s, want %s", i, got, c.sharedSecret)
+		}
+	}
+}
+
+func TestV2ECDHAgreement(t *testing.T) {
+	alice, aliceEncoded, err := ellswift.Create(rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	bob, bobEncoded, err := ellswift.Create(rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	aliceSecret, err := ellswift.V2ECDH(alice, bobEncoded, aliceEncoded, true)
+	if nil != err {
+		t.Fatal(err)
+	}
+	bobSecret, err := ellswift.V2ECDH(bob, aliceEncoded, bobEncoded, false)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(aliceSecret[:], bobSecret[:]) {
+		t.Fatalf("both sides should agree: got %x and %x", aliceSecret, bobSecret)
+	}
+}
+
+// trimZeros strips the leading zeros of the hex string as big.Int.Bytes does
+func trimZeros(s string) string {
+	for len(s) > 2 && "00" == s[:2] {
+		s = s[2:]
+	}
+
+	return s
+}
@@ -0,0 +1,95 @@
+package ethereum
+
+// References:
+//   [EIP55]: Mixed-case checksum address encoding,
+//     https://eips.ethereum.org/EIPS/eip-55
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+)
+
+// AddressLen is the length in bytes of an address
+const AddressLen = 20
+
+// Address is the last 20 bytes of the Keccak-256 of a public key
+type Address [AddressLen]byte
+
+var (
+	// ErrInvalidAddress is returned if the string isn't 0x followed by 40
+	// hex digits
+	ErrInvalidAddress = errors.New("ethereum: invalid address")
+	// ErrAddressChecksum is returned if the mixed-case address fails the
+	// checksum of [EIP55]
+	ErrAddressChecksum = errors.New("ethereum: invalid address checksum")
+)
+
+// PubkeyToAddress derives the address of the public key over secp256k1,
+// i.e., the last 20 bytes of the Keccak-256 of the 64-byte X||Y
+func PubkeyToAddress(pub *ecdsa.PublicKey) (Address, error) {
+	var addr Address
+
+	if elliptic.P256k1() != pub.Curve {
+		return addr, errors.New("ethereum: the public key isn't over secp256k1")
+	}
+
+	data, err := pub.UncompressedEncode()
+	if nil != err {
+		return addr, err
+	}
+	copy(addr[:], Keccak256(data[1:])[12:])
+
+	return addr, nil
+}
+
+// ParseAddress decodes the 0x-prefixed hex address. An address in mixed
+// case must pass the checksum of [EIP55], while one in all lower or all
+// upper case carries no checksum.
+func ParseAddress(s string) (Address, error) {
+	var addr Address
+
+	if (2+2*AddressLen != len(s)) || (("0x" != s[:2]) && ("0X" != s[:2])) {
+		return addr, ErrInvalidAddress
+	}
+	if _, err := hex.Decode(addr[:], []byte(s[2:])); nil != err {
+		return addr, ErrInvalidAddress
+	}
+
+	digits := s[2:]
+	if (strings.ToLower(digits) != digits) && (strings.ToUpper(digits) != digits) &&
+		(addr.Hex()[2:] != digits) {
+		return addr, ErrAddressChecksum
+	}
+
+	return addr, nil
+}
+
+// Hex encodes the address in the mixed-case checksum encoding of [EIP55],
+// where the i-th hex letter is in upper case iff the i-th nibble of the
+// Keccak-256 of the lower-case hex address is at least 8
+func (addr Address) Hex() string {
+	out := []byte(hex.EncodeToString(addr[:]))
+	hash := Keccak256(out)
+
+	for i, c := range out {
+		nibble := hash[i/2] >> 4
+		if 1 == i%2 {
+			nibble = hash[i/2] & 0x0f
+		}
+
+		if (c >= 'a') && (nibble >= 8) {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+
+	return "0x" + string(out)
+}
+
+// String is the same as Hex
+func (addr Address) String() string {
+	return addr.Hex()
+}
@@ -0,0 +1,113 @@
+package ethereum_test
+
+import (
+	stdElliptic "crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/ethereum"
+)
+
+func TestAddressChecksum(t *testing.T) {
+	// the test cases of EIP-55
+	addresses := []string{
+		"0x52908400098527886E0F7030069857D2E4169EE7",
+		"0x8617E340B3D01FA5F11F306F4090FD50E238070D",
+		"0xde709f2102306220921060314715629080e2fb77",
+		"0x27b1fdb04752bbc536007a920d24acb045561c26",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	}
+
+	for _, want := range addresses {
+		addr, err := ethereum.ParseAddress(want)
+		if nil != err {
+			t.Fatalf("%s: %v", want, err)
+		}
+
+		// the all-caps and all-lower cases happen to be their own checksums
+		if got := addr.Hex(); got != want {
+			t.Fatalf("invalid checksum encoding: got %s, want %s", got, want)
+		}
+
+		// the checksum-free forms are always accepted
+		for _, s := range []string{strings.ToLower(want), "0x" + strings.ToUpper(want[2:])} {
+			if other, err := ethereum.ParseAddress(s); (nil != err) || (other != addr) {
+				t.Fatalf("%s: should be parsed as %s, got (%s,%v)", s, want, other, err)
+			}
+		}
+	}
+}
+
+func TestParseAddressInvalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		s    string
+		err  error
+	}{
+		{"bad checksum", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", ethereum.ErrAddressChecksum},
+		{"no prefix", "5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", ethereum.ErrInvalidAddress},
+		{"too short", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA", ethereum.ErrInvalidAddress},
+		{"too long", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed00", ethereum.ErrInvalidAddress},
+		{"non-hex digit", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeg", ethereum.ErrInvalidAddress},
+	}
+
+	for _, c := range testCases {
+		if _, err := ethereum.ParseAddress(c.s); err != c.err {
+			t.Errorf("%s: invalid error: got %v, want %v", c.name, err, c.err)
+		}
+	}
+}
+
+func TestPubkeyToAddress(t *testing.T) {
+	// the signer of the example of EIP-712
+	priv, err := ecdsa.NewPrivateKey(elliptic.P256k1(),
+		new(big.Int).SetBytes(ethereum.Keccak256([]byte("cow"))))
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	const want = "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"
+	if addr, err := ethereum.PubkeyToAddress(&priv.PublicKey); nil != err {
+		t.Fatal(err)
+	} else if addr.String() != want {
+		t.Fatalf("invalid address: got %s, want %s", addr, want)
+	}
+
+	p256, err := ecdsa.GenerateKey(elliptic.FromStd(stdElliptic.P256()), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if _, err := ethereum.PubkeyToAddress(&p256.PublicKey); nil == err {
+		t.Fatal("the key over P-256 should be rejected")
+	}
+}
+
+func TestVerifyAddress(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256k1(), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	addr := addressOf(t, &priv.PublicKey)
+
+	hash := ethereum.Keccak256([]byte("hello"))
+	sig, err := ethereum.Sign(priv, hash)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	if !ethereum.VerifyAddress(hash, sig, addr) {
+		t.Fatal("the signature should be valid")
+	}
+
+	addr[0] ^= 0x01
+	if ethereum.VerifyAddress(hash, sig, addr) {
+		t.Fatal("the signature should be invalid for another address")
+	}
+}
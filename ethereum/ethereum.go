@@ -0,0 +1,17 @@
+// Package ethereum implements the message signing and the address scheme
+// of ethereum over secp256k1.
+package ethereum
+
+import (
+	"golang.org/x/crypto/sha3"
+)
+
+// Keccak256 computes the legacy Keccak-256 of the concatenation of data
+func Keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+
+	return h.Sum(nil)
+}
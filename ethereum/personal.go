@@ -0,0 +1,30 @@
+package ethereum
+
+import (
+	"strconv"
+
+	"github.com/sammy00/crypto/ecdsa"
+)
+
+// TextHash computes the hash of the message for personal_sign, i.e., the
+// version 0x45 of EIP-191, as
+//
+//	Keccak256("\x19Ethereum Signed Message:\n" || len(msg) || msg)
+//
+// where the length goes in decimal.
+func TextHash(msg []byte) []byte {
+	prefix := "\x19Ethereum Signed Message:\n" + strconv.Itoa(len(msg))
+
+	return Keccak256([]byte(prefix), msg)
+}
+
+// SignText signs the message as personal_sign does
+func SignText(priv *ecdsa.PrivateKey, msg []byte) ([]byte, error) {
+	return Sign(priv, TextHash(msg))
+}
+
+// VerifyText checks the signature of the message output by personal_sign is
+// made by the address
+func VerifyText(address Address, msg, sig []byte) bool {
+	return verifySignature(address, TextHash(msg), sig)
+}
@@ -0,0 +1,89 @@
+package ethereum_test
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/ethereum"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestTextHash(t *testing.T) {
+	// the TestTextHash of accounts/accounts_test.go of go-ethereum
+	const want = "a080337ae51c4e064c189e113edd0ba391df9206e2f49db658bb32cf2911730b"
+
+	if got := hex.EncodeToString(ethereum.TextHash([]byte("Hello Joe"))); got != want {
+		t.Fatalf("invalid hash: got %s, want %s", got, want)
+	}
+}
+
+func TestSignText(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256k1(), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P256k1(), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	msg := []byte("hello world")
+	sig, err := ethereum.SignText(priv, msg)
+	if nil != err {
+		t.Fatal(err)
+	} else if ethereum.SignatureLen != len(sig) {
+		t.Fatalf("invalid signature length: got %d, want %d", len(sig), ethereum.SignatureLen)
+	} else if (27 != sig[64]) && (28 != sig[64]) {
+		t.Fatalf("invalid v: %d", sig[64])
+	}
+
+	addr, otherAddr := addressOf(t, &priv.PublicKey), addressOf(t, &other.PublicKey)
+
+	if !ethereum.VerifyText(addr, msg, sig) {
+		t.Fatal("the signature should be valid")
+	}
+	if ethereum.VerifyText(otherAddr, msg, sig) {
+		t.Fatal("the signature should be invalid for another address")
+	}
+	if ethereum.VerifyText(addr, []byte("hello world!"), sig) {
+		t.Fatal("the signature should be invalid for another message")
+	}
+
+	// v as the bare recovery id is accepted as well
+	raw := append([]byte{}, sig...)
+	raw[64] -= 27
+	if !ethereum.VerifyText(addr, msg, raw) {
+		t.Fatal("the signature with v in {0,1} should be valid")
+	}
+
+	for _, v := range []byte{2, 26, 29} {
+		raw[64] = v
+		if ethereum.VerifyText(addr, msg, raw) {
+			t.Fatalf("the signature with v = %d should be invalid", v)
+		}
+	}
+	if ethereum.VerifyText(addr, msg, sig[:64]) {
+		t.Fatal("the truncated signature should be invalid")
+	}
+}
+
+// addressOf derives the address of the public key by hand as the last 20
+// bytes of Keccak-256(X || Y), so as to stay independent of the address
+// derivation of the package
+func addressOf(t *testing.T, pub *ecdsa.PublicKey) ethereum.Address {
+	var xy [64]byte
+	x, y := pub.X.Bytes(), pub.Y.Bytes()
+	copy(xy[32-len(x):32], x)
+	copy(xy[64-len(y):], y)
+
+	h := sha3.NewLegacyKeccak256()
+	h.Write(xy[:])
+
+	var addr ethereum.Address
+	copy(addr[:], h.Sum(nil)[12:])
+
+	return addr
+}
@@ -0,0 +1,233 @@
+// Package rlp implements the recursive length prefix encoding of ethereum,
+// which serializes nested lists of byte strings.
+//
+// Values are encoded from []byte, string, uint64, uint, *big.Int and
+// []interface{} of them, and decoded into a tree of []byte and
+// []interface{}, where the integers are left as their big-endian bytes.
+package rlp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// ErrNonCanonical is returned if the data isn't in the unique minimal
+	// encoding
+	ErrNonCanonical = errors.New("rlp: non-canonical encoding")
+	// ErrTruncated is returned if the data ends before the item does
+	ErrTruncated = errors.New("rlp: truncated data")
+	// ErrTrailingData is returned if the data goes on after the item
+	ErrTrailingData = errors.New("rlp: trailing data after the item")
+)
+
+// Encode encodes v, which is a byte string as []byte or string, a
+// non-negative integer as uint64, uint or *big.Int, or a list of them as
+// []interface{}. The nil *big.Int encodes zero.
+func Encode(v interface{}) ([]byte, error) {
+	return appendValue(nil, v)
+}
+
+// appendValue appends the encoding of v to out
+func appendValue(out []byte, v interface{}) ([]byte, error) {
+	switch vv := v.(type) {
+	case []byte:
+		return appendString(out, vv), nil
+	case string:
+		return appendString(out, []byte(vv)), nil
+	case uint64:
+		return appendUint(out, vv), nil
+	case uint:
+		return appendUint(out, uint64(vv)), nil
+	case *big.Int:
+		if nil == vv {
+			return appendString(out, nil), nil
+		} else if vv.Sign() < 0 {
+			return nil, errors.New("rlp: negative integer")
+		}
+		return appendString(out, vv.Bytes()), nil
+	case []interface{}:
+		var payload []byte
+		for _, item := range vv {
+			var err error
+			if payload, err = appendValue(payload, item); nil != err {
+				return nil, err
+			}
+		}
+		return append(appendHeader(out, 0xc0, len(payload)), payload...), nil
+	}
+
+	return nil, fmt.Errorf("rlp: unsupported type %T", v)
+}
+
+// appendUint encodes the integer as its big-endian bytes without leading
+// zeros
+func appendUint(out []byte, x uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], x)
+
+	i := 0
+	for (i < 8) && (0 == b[i]) {
+		i++
+	}
+
+	return appendString(out, b[i:])
+}
+
+// appendString encodes the byte string, where a single byte below 0x80 is
+// its own encoding
+func appendString(out, s []byte) []byte {
+	if (1 == len(s)) && (s[0] < 0x80) {
+		return append(out, s[0])
+	}
+
+	return append(appendHeader(out, 0x80, len(s)), s...)
+}
+
+// appendHeader appends the prefix of the string (offset 0x80) or the list
+// (offset 0xc0) of n bytes
+func appendHeader(out []byte, offset byte, n int) []byte {
+	if n < 56 {
+		return append(out, offset+byte(n))
+	}
+
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], uint64(n))
+
+	i := 0
+	for 0 == b[i] {
+		i++
+	}
+
+	out = append(out, offset+55+byte(8-i))
+	return append(out, b[i:]...)
+}
+
+// Decode decodes the single item making up the whole data into []byte or
+// []interface{}, rejecting any non-canonical encoding
+func Decode(data []byte) (interface{}, error) {
+	v, rest, err := decodeValue(data)
+	if nil != err {
+		return nil, err
+	} else if 0 != len(rest) {
+		return nil, ErrTrailingData
+	}
+
+	return v, nil
+}
+
+// decodeValue decodes the first item of data, and returns the remaining
+// bytes
+func decodeValue(data []byte) (v interface{}, rest []byte, err error) {
+	isList, payload, rest, err := split(data)
+	if nil != err {
+		return nil, nil, err
+	}
+
+	if !isList {
+		return payload, rest, nil
+	}
+
+	list := []interface{}{}
+	for 0 != len(payload) {
+		var item interface{}
+		if item, payload, err = decodeValue(payload); nil != err {
+			return nil, nil, err
+		}
+		list = append(list, item)
+	}
+
+	return list, rest, nil
+}
+
+// split parses the header of the first item of data, and returns its
+// payload along with the remaining bytes
+func split(data []byte) (isList bool, payload, rest []byte, err error) {
+	if 0 == len(data) {
+		return false, nil, nil, ErrTruncated
+	}
+
+	prefix := data[0]
+	var offset, n int
+	switch {
+	case prefix < 0x80:
+		return false, data[:1], data[1:], nil
+	case prefix < 0xb8:
+		offset, n = 1, int(prefix-0x80)
+		if (1 == n) && (len(data) > 1) && (data[1] < 0x80) {
+			return false, nil, nil, ErrNonCanonical
+		}
+	case prefix < 0xc0:
+		offset, n, err = readLength(data, int(prefix-0xb7))
+	case prefix < 0xf8:
+		isList, offset, n = true, 1, int(prefix-0xc0)
+	default:
+		isList = true
+		offset, n, err = readLength(data, int(prefix-0xf7))
+	}
+	if nil != err {
+		return false, nil, nil, err
+	}
+
+	if n > len(data)-offset {
+		return false, nil, nil, ErrTruncated
+	}
+
+	return isList, data[offset : offset+n], data[offset+n:], nil
+}
+
+// readLength reads the big-endian length of lenOfLen bytes following the
+// prefix, which must have no leading zeros, be at least 56 and fit in the
+// remaining data. The bound is checked before converting the length to int,
+// which may be of 32 bits.
+func readLength(data []byte, lenOfLen int) (offset, n int, err error) {
+	if len(data) < 1+lenOfLen {
+		return 0, 0, ErrTruncated
+	}
+	if 0 == data[1] {
+		return 0, 0, ErrNonCanonical
+	}
+
+	// lenOfLen is at most 8 as the prefix is at most 0xbf or 0xff
+	var length uint64
+	for _, b := range data[1 : 1+lenOfLen] {
+		length = (length << 8) | uint64(b)
+	}
+	if length < 56 {
+		return 0, 0, ErrNonCanonical
+	}
+	if length > uint64(len(data)-1-lenOfLen) {
+		return 0, 0, ErrTruncated
+	}
+
+	return 1 + lenOfLen, int(length), nil
+}
+
+// Uint64 decodes the integer from its canonical big-endian bytes without
+// leading zeros
+func Uint64(b []byte) (uint64, error) {
+	if len(b) > 8 {
+		return 0, errors.New("rlp: the integer overflows uint64")
+	} else if (0 != len(b)) && (0 == b[0]) {
+		return 0, ErrNonCanonical
+	}
+
+	var x uint64
+	for _, c := range b {
+		x = (x << 8) | uint64(c)
+	}
+
+	return x, nil
+}
+
+// BigInt decodes the integer from its canonical big-endian bytes without
+// leading zeros
+func BigInt(b []byte) (*big.Int, error) {
+	if (0 != len(b)) && (0 == b[0]) {
+		return nil, ErrNonCanonical
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
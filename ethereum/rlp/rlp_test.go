@@ -0,0 +1,131 @@
+package rlp_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sammy00/crypto/ethereum/rlp"
+)
+
+func TestEncode(t *testing.T) {
+	bigInt, _ := new(big.Int).SetString("102030405060708090a0b0c0d0e0f2", 16)
+	lorem := "Lorem ipsum dolor sit amet, consectetur adipisicing elit"
+
+	// the examples of the RLP page of the ethereum wiki
+	testCases := []struct {
+		v    interface{}
+		want string
+	}{
+		{"dog", "83646f67"},
+		{[]interface{}{"cat", "dog"}, "c88363617483646f67"},
+		{"", "80"},
+		{[]interface{}{}, "c0"},
+		{uint64(0), "80"},
+		{[]byte{0x00}, "00"},
+		{[]byte{0x0f}, "0f"},
+		{[]byte{0x04, 0x00}, "820400"},
+		{uint(1024), "820400"},
+		{[]interface{}{[]interface{}{}, []interface{}{[]interface{}{}},
+			[]interface{}{[]interface{}{}, []interface{}{[]interface{}{}}}},
+			"c7c0c1c0c3c0c1c0"},
+		{lorem, "b838" + hex.EncodeToString([]byte(lorem))},
+		{bigInt, "8f102030405060708090a0b0c0d0e0f2"},
+		{(*big.Int)(nil), "80"},
+	}
+
+	for i, c := range testCases {
+		got, err := rlp.Encode(c.v)
+		if nil != err {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if hex.EncodeToString(got) != c.want {
+			t.Fatalf("#%d: invalid encoding: got %x, want %s", i, got, c.want)
+		}
+	}
+
+	for _, v := range []interface{}{big.NewInt(-1), 1, []interface{}{int8(1)}} {
+		if _, err := rlp.Encode(v); nil == err {
+			t.Fatalf("encoding %v should fail", v)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	long := strings.Repeat("a", 1024)
+	values := []interface{}{
+		[]byte("dog"),
+		[]byte{},
+		[]byte{0x00},
+		[]byte(long),
+		[]interface{}{[]byte("cat"), []interface{}{[]byte(long), []interface{}{}}},
+	}
+
+	for i, v := range values {
+		data, err := rlp.Encode(v)
+		if nil != err {
+			t.Fatalf("#%d: %v", i, err)
+		}
+
+		got, err := rlp.Decode(data)
+		if nil != err {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if !reflect.DeepEqual(got, v) {
+			t.Fatalf("#%d: invalid decoding: got %v, want %v", i, got, v)
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+		err  error
+	}{
+		{"empty", "", rlp.ErrTruncated},
+		{"single byte as string", "8100", rlp.ErrNonCanonical},
+		{"short string in long form", "b803646f67", rlp.ErrNonCanonical},
+		{"length with leading zero", "b90038" + strings.Repeat("61", 56), rlp.ErrNonCanonical},
+		{"short list in long form", "f800", rlp.ErrNonCanonical},
+		{"truncated string", "83646f", rlp.ErrTruncated},
+		{"truncated list", "c88363617483646f", rlp.ErrTruncated},
+		{"truncated length", "b9", rlp.ErrTruncated},
+		{"string beyond int32", "bb80000000" + strings.Repeat("61", 56), rlp.ErrTruncated},
+		{"string beyond int64", "bfffffffffffffffff" + strings.Repeat("61", 56), rlp.ErrTruncated},
+		{"list beyond int64", "ffffffffffffffffff" + strings.Repeat("c0", 56), rlp.ErrTruncated},
+		{"trailing data", "83646f6700", rlp.ErrTrailingData},
+	}
+
+	for _, c := range testCases {
+		data, _ := hex.DecodeString(c.data)
+		if _, err := rlp.Decode(data); err != c.err {
+			t.Errorf("%s: invalid error: got %v, want %v", c.name, err, c.err)
+		}
+	}
+}
+
+func TestIntegers(t *testing.T) {
+	if x, err := rlp.Uint64([]byte{0x04, 0x00}); (nil != err) || (1024 != x) {
+		t.Fatalf("invalid uint64: got (%d,%v)", x, err)
+	}
+	if x, err := rlp.Uint64(nil); (nil != err) || (0 != x) {
+		t.Fatalf("invalid zero: got (%d,%v)", x, err)
+	}
+	if _, err := rlp.Uint64([]byte{0x00, 0x01}); rlp.ErrNonCanonical != err {
+		t.Fatalf("leading zeros should be rejected: got %v", err)
+	}
+	if _, err := rlp.Uint64(bytes.Repeat([]byte{0x01}, 9)); nil == err {
+		t.Fatal("overflows should be rejected")
+	}
+
+	if x, err := rlp.BigInt([]byte{0x01, 0x00}); (nil != err) || (256 != x.Int64()) {
+		t.Fatalf("invalid big integer: got (%v,%v)", x, err)
+	}
+	if _, err := rlp.BigInt([]byte{0x00}); rlp.ErrNonCanonical != err {
+		t.Fatalf("leading zeros should be rejected: got %v", err)
+	}
+}
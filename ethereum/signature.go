@@ -0,0 +1,82 @@
+package ethereum
+
+import (
+	"errors"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+)
+
+// SignatureLen is the length in bytes of a signature r||s||v
+const SignatureLen = 65
+
+// ErrInvalidSignature is returned if a signature is malformed or recovers
+// no public key
+var ErrInvalidSignature = errors.New("ethereum: invalid signature")
+
+// Sign signs the 32-byte hash with the secp256k1 key as libsecp256k1 does,
+// i.e., with the RFC 6979 nonce and a low s, and outputs r||s||v, where v
+// is 27 plus the recovery id as expected by wallets and ecrecover
+func Sign(priv *ecdsa.PrivateKey, hash []byte) ([]byte, error) {
+	if elliptic.P256k1() != priv.Curve {
+		return nil, errors.New("ethereum: the private key isn't over secp256k1")
+	}
+	if 32 != len(hash) {
+		return nil, errors.New("ethereum: the hash must be 32 bytes")
+	}
+
+	compact, err := ecdsa.SignCompact(priv, hash, false)
+	if nil != err {
+		return nil, err
+	}
+
+	// compact is v||r||s
+	sig := append(compact[1:], compact[0])
+
+	return sig, nil
+}
+
+// RecoverPublicKey recovers the public key from the signature r||s||v of
+// the hash, where v is either the recovery id or 27 plus it
+func RecoverPublicKey(hash, sig []byte) (*ecdsa.PublicKey, error) {
+	if SignatureLen != len(sig) {
+		return nil, ErrInvalidSignature
+	}
+
+	v := sig[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	// the compact signature of bitcoin goes as (27+v)||r||s
+	compact := append([]byte{27 + v}, sig[:64]...)
+
+	pub, _, err := ecdsa.RecoverCompact(elliptic.P256k1(), compact, hash)
+	if nil != err {
+		return nil, ErrInvalidSignature
+	}
+
+	return pub, nil
+}
+
+// VerifyAddress checks the public key recovered from the signature r||s||v
+// of the hash is of the address
+func VerifyAddress(hash, sig []byte, address Address) bool {
+	return verifySignature(address, hash, sig)
+}
+
+// verifySignature checks the public key recovered from the signature of the
+// hash is of the address
+func verifySignature(address Address, hash, sig []byte) bool {
+	pub, err := RecoverPublicKey(hash, sig)
+	if nil != err {
+		return false
+	}
+
+	addr, err := PubkeyToAddress(pub)
+
+	return (nil == err) && (addr == address)
+}
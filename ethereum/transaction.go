@@ -0,0 +1,423 @@
+package ethereum
+
+// References:
+//   [EIP155]: Simple replay attack protection,
+//     https://eips.ethereum.org/EIPS/eip-155
+//   [EIP2718]: Typed transaction envelope,
+//     https://eips.ethereum.org/EIPS/eip-2718
+//   [EIP2930]: Optional access lists,
+//     https://eips.ethereum.org/EIPS/eip-2930
+//   [EIP1559]: Fee market change for ETH 1.0 chain,
+//     https://eips.ethereum.org/EIPS/eip-1559
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/ethereum/rlp"
+	"github.com/sammy00/crypto/misc"
+)
+
+// the transaction types of [EIP2718]
+const (
+	LegacyTxType     byte = 0x00
+	AccessListTxType byte = 0x01
+	DynamicFeeTxType byte = 0x02
+)
+
+// ErrInvalidTransaction is returned if a transaction can't be decoded
+var ErrInvalidTransaction = errors.New("ethereum: invalid transaction")
+
+// AccessTuple is an entry of the access list of [EIP2930]
+type AccessTuple struct {
+	Address     Address
+	StorageKeys [][32]byte
+}
+
+// Transaction is a legacy, access-list or dynamic-fee transaction as Type
+// tells. GasPrice only goes with the former two, while GasTipCap and
+// GasFeeCap only go with the dynamic-fee one. A nil To creates a contract.
+//
+// V is 27 plus the recovery id for legacy transactions without ChainID,
+// the recovery id plus 35+2*ChainID for those with it, and the recovery id
+// itself for typed transactions.
+type Transaction struct {
+	Type       byte
+	ChainID    *big.Int
+	Nonce      uint64
+	GasPrice   *big.Int
+	GasTipCap  *big.Int
+	GasFeeCap  *big.Int
+	Gas        uint64
+	To         *Address
+	Value      *big.Int
+	Data       []byte
+	AccessList []AccessTuple
+
+	V, R, S *big.Int
+}
+
+// isProtected tells if the legacy transaction commits to the chain ID as
+// [EIP155] specifies
+func (tx *Transaction) isProtected() bool {
+	return (nil != tx.ChainID) && (0 != tx.ChainID.Sign())
+}
+
+// fields lists the fields to sign of the transaction
+func (tx *Transaction) fields() ([]interface{}, error) {
+	var to []byte
+	if nil != tx.To {
+		to = tx.To[:]
+	}
+
+	switch tx.Type {
+	case LegacyTxType:
+		return []interface{}{tx.Nonce, tx.GasPrice, tx.Gas, to, tx.Value, tx.Data}, nil
+	case AccessListTxType:
+		return []interface{}{tx.ChainID, tx.Nonce, tx.GasPrice, tx.Gas, to, tx.Value,
+			tx.Data, encodeAccessList(tx.AccessList)}, nil
+	case DynamicFeeTxType:
+		return []interface{}{tx.ChainID, tx.Nonce, tx.GasTipCap, tx.GasFeeCap, tx.Gas,
+			to, tx.Value, tx.Data, encodeAccessList(tx.AccessList)}, nil
+	}
+
+	return nil, fmt.Errorf("ethereum: unknown transaction type %d", tx.Type)
+}
+
+// encodeAccessList prepares the access list for RLP
+func encodeAccessList(list []AccessTuple) []interface{} {
+	out := make([]interface{}, len(list))
+	for i := range list {
+		// slice the elements in place rather than copies of them
+		tuple := &list[i]
+
+		keys := make([]interface{}, len(tuple.StorageKeys))
+		for j := range tuple.StorageKeys {
+			keys[j] = tuple.StorageKeys[j][:]
+		}
+		out[i] = []interface{}{tuple.Address[:], keys}
+	}
+
+	return out
+}
+
+// encode serializes the transaction with the given trailing fields, prefixed
+// by the type byte for typed transactions
+func (tx *Transaction) encode(trailer ...interface{}) ([]byte, error) {
+	fields, err := tx.fields()
+	if nil != err {
+		return nil, err
+	}
+
+	data, err := rlp.Encode(append(fields, trailer...))
+	if nil != err {
+		return nil, err
+	}
+
+	if LegacyTxType == tx.Type {
+		return data, nil
+	}
+
+	return append([]byte{tx.Type}, data...), nil
+}
+
+// SigningHash computes the hash to sign, which is the Keccak-256 of
+// RLP([nonce, gasPrice, gas, to, value, data]) for legacy transactions
+// without ChainID, with chainID, 0, 0 appended by [EIP155] otherwise, and of
+// the type byte followed by the RLP of the fields for typed ones.
+func (tx *Transaction) SigningHash() ([]byte, error) {
+	var trailer []interface{}
+	if (LegacyTxType == tx.Type) && tx.isProtected() {
+		trailer = []interface{}{tx.ChainID, uint64(0), uint64(0)}
+	}
+
+	data, err := tx.encode(trailer...)
+	if nil != err {
+		return nil, err
+	}
+
+	return Keccak256(data), nil
+}
+
+// Sign signs the transaction with the secp256k1 key, filling in V, R and S
+func (tx *Transaction) Sign(priv *ecdsa.PrivateKey) error {
+	hash, err := tx.SigningHash()
+	if nil != err {
+		return err
+	}
+
+	sig, err := Sign(priv, hash)
+	if nil != err {
+		return err
+	}
+
+	recid := int64(sig[64] - 27)
+	switch {
+	case LegacyTxType != tx.Type:
+		tx.V = big.NewInt(recid)
+	case tx.isProtected():
+		tx.V = new(big.Int).Lsh(tx.ChainID, 1)
+		tx.V.Add(tx.V, big.NewInt(35+recid))
+	default:
+		tx.V = big.NewInt(27 + recid)
+	}
+	tx.R = new(big.Int).SetBytes(sig[:32])
+	tx.S = new(big.Int).SetBytes(sig[32:64])
+
+	return nil
+}
+
+// recoveryID extracts the recovery id from V
+func (tx *Transaction) recoveryID() (byte, error) {
+	if nil == tx.V {
+		return 0, errors.New("ethereum: the transaction isn't signed")
+	}
+
+	v := new(big.Int).Set(tx.V)
+	switch {
+	case LegacyTxType != tx.Type:
+	case tx.isProtected():
+		v.Sub(v, big.NewInt(35))
+		v.Sub(v, new(big.Int).Lsh(tx.ChainID, 1))
+	default:
+		v.Sub(v, big.NewInt(27))
+	}
+
+	if !v.IsInt64() || (v.Int64() < 0) || (v.Int64() > 1) {
+		return 0, errors.New("ethereum: invalid V for the transaction")
+	}
+
+	return byte(v.Int64()), nil
+}
+
+// Sender recovers the address signing the transaction, where s must be in
+// the lower half of the group order as is required since Homestead
+func (tx *Transaction) Sender() (Address, error) {
+	recid, err := tx.recoveryID()
+	if nil != err {
+		return Address{}, err
+	}
+
+	if (nil == tx.R) || (nil == tx.S) || !ecdsa.IsLowS(elliptic.P256k1(), tx.S) ||
+		(tx.R.BitLen() > 256) {
+		return Address{}, ErrInvalidSignature
+	}
+
+	sig := make([]byte, SignatureLen)
+	misc.ReverseCopy(sig[:32], tx.R.Bytes())
+	misc.ReverseCopy(sig[32:64], tx.S.Bytes())
+	sig[64] = recid
+
+	hash, err := tx.SigningHash()
+	if nil != err {
+		return Address{}, err
+	}
+
+	pub, err := RecoverPublicKey(hash, sig)
+	if nil != err {
+		return Address{}, err
+	}
+
+	return PubkeyToAddress(pub)
+}
+
+// MarshalBinary encodes the signed transaction as is sent over the network,
+// i.e., RLP([fields..., v, r, s]), prefixed by the type byte for typed
+// transactions
+func (tx *Transaction) MarshalBinary() ([]byte, error) {
+	return tx.encode(tx.V, tx.R, tx.S)
+}
+
+// Hash computes the transaction hash, i.e., the Keccak-256 of the output of
+// MarshalBinary
+func (tx *Transaction) Hash() ([]byte, error) {
+	data, err := tx.MarshalBinary()
+	if nil != err {
+		return nil, err
+	}
+
+	return Keccak256(data), nil
+}
+
+// UnmarshalBinary decodes the signed transaction output by MarshalBinary.
+// The ChainID of legacy transactions is derived from V.
+func (tx *Transaction) UnmarshalBinary(data []byte) error {
+	if 0 == len(data) {
+		return ErrInvalidTransaction
+	}
+
+	// a legacy transaction is an RLP list starting from 0xc0
+	decoded := Transaction{Type: LegacyTxType}
+	if data[0] < 0xc0 {
+		if LegacyTxType == data[0] {
+			return ErrInvalidTransaction
+		}
+		decoded.Type, data = data[0], data[1:]
+	}
+
+	v, err := rlp.Decode(data)
+	if nil != err {
+		return err
+	}
+	items, ok := v.([]interface{})
+	if !ok {
+		return ErrInvalidTransaction
+	}
+
+	var d txDecoder
+	switch decoded.Type {
+	case LegacyTxType:
+		d.items, d.n = items, 9
+		decoded.Nonce, decoded.GasPrice = d.uint64(), d.bigInt()
+		decoded.Gas, decoded.To = d.uint64(), d.address()
+		decoded.Value, decoded.Data = d.bigInt(), d.bytes()
+	case AccessListTxType:
+		d.items, d.n = items, 11
+		decoded.ChainID, decoded.Nonce, decoded.GasPrice = d.bigInt(), d.uint64(), d.bigInt()
+		decoded.Gas, decoded.To = d.uint64(), d.address()
+		decoded.Value, decoded.Data, decoded.AccessList = d.bigInt(), d.bytes(), d.accessList()
+	case DynamicFeeTxType:
+		d.items, d.n = items, 12
+		decoded.ChainID, decoded.Nonce = d.bigInt(), d.uint64()
+		decoded.GasTipCap, decoded.GasFeeCap = d.bigInt(), d.bigInt()
+		decoded.Gas, decoded.To = d.uint64(), d.address()
+		decoded.Value, decoded.Data, decoded.AccessList = d.bigInt(), d.bytes(), d.accessList()
+	default:
+		return fmt.Errorf("ethereum: unknown transaction type %d", decoded.Type)
+	}
+	decoded.V, decoded.R, decoded.S = d.bigInt(), d.bigInt(), d.bigInt()
+
+	if nil != d.err {
+		return d.err
+	}
+
+	if (LegacyTxType == decoded.Type) && (decoded.V.Cmp(big.NewInt(35)) >= 0) {
+		// V = recid + 35 + 2*chainID
+		decoded.ChainID = new(big.Int).Sub(decoded.V, big.NewInt(35))
+		decoded.ChainID.Rsh(decoded.ChainID, 1)
+	}
+
+	*tx = decoded
+	return nil
+}
+
+// txDecoder reads the decoded RLP items one after another, keeping the first
+// error only
+type txDecoder struct {
+	items []interface{}
+	n     int
+	err   error
+}
+
+// next returns the next item as a byte string
+func (d *txDecoder) next() []byte {
+	if nil != d.err {
+		return nil
+	}
+	if len(d.items) != d.n {
+		d.err = ErrInvalidTransaction
+		return nil
+	}
+
+	b, ok := d.items[0].([]byte)
+	if !ok {
+		d.err = ErrInvalidTransaction
+		return nil
+	}
+	d.items, d.n = d.items[1:], d.n-1
+
+	return b
+}
+
+func (d *txDecoder) bytes() []byte {
+	return d.next()
+}
+
+func (d *txDecoder) uint64() uint64 {
+	x, err := rlp.Uint64(d.next())
+	if nil == d.err {
+		d.err = err
+	}
+
+	return x
+}
+
+func (d *txDecoder) bigInt() *big.Int {
+	x, err := rlp.BigInt(d.next())
+	if nil == d.err {
+		d.err = err
+	}
+
+	return x
+}
+
+// address reads the recipient, which is empty for contract creation
+func (d *txDecoder) address() *Address {
+	b := d.next()
+	if (nil != d.err) || (0 == len(b)) {
+		return nil
+	}
+	if AddressLen != len(b) {
+		d.err = ErrInvalidTransaction
+		return nil
+	}
+
+	var addr Address
+	copy(addr[:], b)
+
+	return &addr
+}
+
+// accessList reads the access list of [EIP2930]
+func (d *txDecoder) accessList() []AccessTuple {
+	if nil != d.err {
+		return nil
+	}
+	if len(d.items) != d.n {
+		d.err = ErrInvalidTransaction
+		return nil
+	}
+
+	list, ok := d.items[0].([]interface{})
+	if !ok {
+		d.err = ErrInvalidTransaction
+		return nil
+	}
+	d.items, d.n = d.items[1:], d.n-1
+
+	out := make([]AccessTuple, 0, len(list))
+	for _, item := range list {
+		tuple, ok := item.([]interface{})
+		if !ok || (2 != len(tuple)) {
+			d.err = ErrInvalidTransaction
+			return nil
+		}
+
+		addr, ok := tuple[0].([]byte)
+		keys, ok2 := tuple[1].([]interface{})
+		if !ok || !ok2 || (AddressLen != len(addr)) {
+			d.err = ErrInvalidTransaction
+			return nil
+		}
+
+		var t AccessTuple
+		copy(t.Address[:], addr)
+		for _, key := range keys {
+			k, ok := key.([]byte)
+			if !ok || (32 != len(k)) {
+				d.err = ErrInvalidTransaction
+				return nil
+			}
+
+			var storageKey [32]byte
+			copy(storageKey[:], k)
+			t.StorageKeys = append(t.StorageKeys, storageKey)
+		}
+		out = append(out, t)
+	}
+
+	return out
+}
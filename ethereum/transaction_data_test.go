@@ -0,0 +1,48 @@
+package ethereum_test
+
+// the example of EIP-155, signed by 0x4646...46
+const (
+	eip155Key         = "4646464646464646464646464646464646464646464646464646464646464646"
+	eip155SigningData = "ec098504a817c800825208943535353535353535353535353535353535353535" +
+		"880de0b6b3a764000080018080"
+	eip155SigningHash = "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53"
+	eip155R           = "18515461264373351373200002665853028612451056578545711640558177340181847433846"
+	eip155S           = "46948507304638947509940763649030358759909902576025900602547168820602576006531"
+	eip155SignedTx    = "f86c098504a817c800825208943535353535353535353535353535353535353535" +
+		"880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa63" +
+		"6276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"
+)
+
+// the access-list transaction of TestEIP2718TransactionSigHash and
+// TestEIP2718TransactionEncode in core/types/transaction_test.go of
+// go-ethereum, whose signature is attached by WithSignature
+const (
+	gethTestAddr       = "0xb94f5374fce5edbc8e2a8697c15331677e6ebf0b"
+	gethEIP2718SigHash = "49b486f0ec0a60dfbbca2d30cb07c9e8ffb2a2ff41f29a1ab6737475f6ff69f3"
+	gethEIP2718R       = "c9519f4f2b30335884581971573fadf60c6204f59a911df35ee8a540456b2660"
+	gethEIP2718S       = "32f1e8e2c5dd761f9e4f88f41c8310aeaba26a8bfcdacfedfa12ec3862d37521"
+	gethEIP2718Encoded = "01f8630103018261a894b94f5374fce5edbc8e2a8697c15331677e6ebf0b0a8255" +
+		"44c001a0c9519f4f2b30335884581971573fadf60c6204f59a911df35ee8a540456b2660a032f1e8e2c5dd" +
+		"761f9e4f88f41c8310aeaba26a8bfcdacfedfa12ec3862d37521"
+)
+
+// a dynamic-fee transaction signed by the key of EIP-155 as the LondonSigner
+// of go-ethereum does, i.e., by libsecp256k1 with the nonce of RFC 6979. It
+// is not output by go-ethereum itself, but computed by an independent Python
+// implementation of RLP, Keccak-256 and secp256k1, which reproduces
+// eip155SignedTx and gethEIP2718SigHash above as well.
+const (
+	eip1559Sender      = "0x9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f"
+	eip1559SigningData = "02f88e010984773594008509502f900082ea6094353535353535353535353535353535353535353588" +
+		"0de0b6b3a7640000825544f85bf85994b94f5374fce5edbc8e2a8697c15331677e6ebf0bf842a00000000000" +
+		"000000000000000000000000000000000000000000000000000001a000000000000000000000000000000000" +
+		"00000000000000000000000000000002"
+	eip1559SigningHash = "5ac483c4e9005ed193c537bb288eac4af3be6c105e4dab67c0e7e249d39106b9"
+	eip1559R           = "ddf0730272cdc69e999510107443c840d76a4da43ef8f0552a0e462d64b62379"
+	eip1559S           = "637118cdddf818d3fe4b645729de6801d53b33bf458043eff7eabd8b2db68571"
+	eip1559SignedTx    = "02f8d1010984773594008509502f900082ea6094353535353535353535353535353535353535353588" +
+		"0de0b6b3a7640000825544f85bf85994b94f5374fce5edbc8e2a8697c15331677e6ebf0bf842a00000000000" +
+		"000000000000000000000000000000000000000000000000000001a000000000000000000000000000000000" +
+		"0000000000000000000000000000000280a0ddf0730272cdc69e999510107443c840d76a4da43ef8f0552a0e" +
+		"462d64b62379a0637118cdddf818d3fe4b645729de6801d53b33bf458043eff7eabd8b2db68571"
+)
@@ -0,0 +1,355 @@
+package ethereum_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/ethereum"
+	"github.com/sammy00/crypto/ethereum/rlp"
+)
+
+func TestLegacyTransactionEIP155(t *testing.T) {
+	to := ethereum.Address{}
+	copy(to[:], bytes.Repeat([]byte{0x35}, ethereum.AddressLen))
+
+	tx := &ethereum.Transaction{
+		Type:     ethereum.LegacyTxType,
+		ChainID:  big.NewInt(1),
+		Nonce:    9,
+		GasPrice: big.NewInt(20000000000),
+		Gas:      21000,
+		To:       &to,
+		Value:    new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil),
+	}
+
+	hash, err := tx.SigningHash()
+	if nil != err {
+		t.Fatal(err)
+	}
+	wantData, _ := hex.DecodeString(eip155SigningData)
+	if got := hex.EncodeToString(hash); got != hex.EncodeToString(ethereum.Keccak256(wantData)) ||
+		got != eip155SigningHash {
+		t.Fatalf("invalid signing hash: got %s, want %s", got, eip155SigningHash)
+	}
+
+	priv := privateKeyFromHex(t, eip155Key)
+	if err := tx.Sign(priv); nil != err {
+		t.Fatal(err)
+	}
+	if (37 != tx.V.Int64()) || (eip155R != tx.R.String()) || (eip155S != tx.S.String()) {
+		t.Fatalf("invalid signature: got (%v,%v,%v)", tx.V, tx.R, tx.S)
+	}
+
+	data, err := tx.MarshalBinary()
+	if nil != err {
+		t.Fatal(err)
+	} else if got := hex.EncodeToString(data); got != eip155SignedTx {
+		t.Fatalf("invalid encoding: got %s, want %s", got, eip155SignedTx)
+	}
+
+	decoded := new(ethereum.Transaction)
+	if err := decoded.UnmarshalBinary(data); nil != err {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(normalize(decoded), normalize(tx)) {
+		t.Fatalf("invalid decoding: got %+v, want %+v", decoded, tx)
+	}
+
+	sender, err := decoded.Sender()
+	if nil != err {
+		t.Fatal(err)
+	} else if want := addressOf(t, &priv.PublicKey); sender != want {
+		t.Fatalf("invalid sender: got %s, want %s", sender, want)
+	}
+}
+
+func TestAccessListTransactionGeth(t *testing.T) {
+	to, err := ethereum.ParseAddress(gethTestAddr)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	tx := &ethereum.Transaction{
+		Type:     ethereum.AccessListTxType,
+		ChainID:  big.NewInt(1),
+		Nonce:    3,
+		GasPrice: big.NewInt(1),
+		Gas:      25000,
+		To:       &to,
+		Value:    big.NewInt(10),
+		Data:     []byte{0x55, 0x44},
+	}
+
+	hash, err := tx.SigningHash()
+	if nil != err {
+		t.Fatal(err)
+	} else if got := hex.EncodeToString(hash); got != gethEIP2718SigHash {
+		t.Fatalf("invalid signing hash: got %s, want %s", got, gethEIP2718SigHash)
+	}
+
+	tx.V = big.NewInt(1)
+	tx.R, _ = new(big.Int).SetString(gethEIP2718R, 16)
+	tx.S, _ = new(big.Int).SetString(gethEIP2718S, 16)
+
+	data, err := tx.MarshalBinary()
+	if nil != err {
+		t.Fatal(err)
+	} else if got := hex.EncodeToString(data); got != gethEIP2718Encoded {
+		t.Fatalf("invalid encoding: got %s, want %s", got, gethEIP2718Encoded)
+	}
+
+	decoded := new(ethereum.Transaction)
+	if err := decoded.UnmarshalBinary(data); nil != err {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(normalize(decoded), normalize(tx)) {
+		t.Fatalf("invalid decoding: got %+v, want %+v", decoded, tx)
+	}
+
+	// the sender is whoever the signature recovers to
+	sender, err := decoded.Sender()
+	if nil != err {
+		t.Fatal(err)
+	}
+	sig := append(append(tx.R.Bytes(), tx.S.Bytes()...), 1)
+	if !ethereum.VerifyAddress(hash, sig, sender) {
+		t.Fatalf("the signature should be made by %s", sender)
+	}
+}
+
+func TestTransactionRoundTrip(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256k1(), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	from := addressOf(t, &priv.PublicKey)
+
+	to := ethereum.Address{0x01, 0x02}
+	accessList := []ethereum.AccessTuple{
+		{Address: to, StorageKeys: [][32]byte{{0x01}, {0x02}}},
+		{Address: ethereum.Address{0xff}, StorageKeys: [][32]byte{}},
+	}
+
+	testCases := []struct {
+		name string
+		tx   *ethereum.Transaction
+	}{
+		{"legacy without chain ID", &ethereum.Transaction{
+			Type: ethereum.LegacyTxType, Nonce: 1, GasPrice: big.NewInt(7), Gas: 21000,
+			To: &to, Value: big.NewInt(1)}},
+		{"legacy with large chain ID", &ethereum.Transaction{
+			Type: ethereum.LegacyTxType, ChainID: big.NewInt(1337133713371337), Gas: 21000,
+			To: &to, Value: big.NewInt(1), GasPrice: big.NewInt(1)}},
+		{"access list", &ethereum.Transaction{
+			Type: ethereum.AccessListTxType, ChainID: big.NewInt(5), Nonce: 2,
+			GasPrice: big.NewInt(30), Gas: 50000, To: &to, Value: big.NewInt(0),
+			Data: []byte("call"), AccessList: accessList}},
+		{"dynamic fee", &ethereum.Transaction{
+			Type: ethereum.DynamicFeeTxType, ChainID: big.NewInt(1), Nonce: 300,
+			GasTipCap: big.NewInt(2000000000), GasFeeCap: big.NewInt(100000000000),
+			Gas: 60000, To: &to, Value: big.NewInt(12345), AccessList: accessList}},
+		{"contract creation", &ethereum.Transaction{
+			Type: ethereum.DynamicFeeTxType, ChainID: big.NewInt(10), GasTipCap: big.NewInt(1),
+			GasFeeCap: big.NewInt(2), Gas: 1000000, Value: big.NewInt(0),
+			Data: bytes.Repeat([]byte{0x60}, 100)}},
+	}
+
+	for _, c := range testCases {
+		if err := c.tx.Sign(priv); nil != err {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		data, err := c.tx.MarshalBinary()
+		if nil != err {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+
+		decoded := new(ethereum.Transaction)
+		if err := decoded.UnmarshalBinary(data); nil != err {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(normalize(decoded), normalize(c.tx)) {
+			t.Fatalf("%s: invalid decoding: got %+v, want %+v", c.name, decoded, c.tx)
+		}
+
+		if sender, err := decoded.Sender(); nil != err {
+			t.Fatalf("%s: %v", c.name, err)
+		} else if sender != from {
+			t.Fatalf("%s: invalid sender: got %s, want %s", c.name, sender, from)
+		}
+
+		// the signature commits to every field
+		decoded.Nonce++
+		if sender, err := decoded.Sender(); (nil == err) && (sender == from) {
+			t.Fatalf("%s: the sender should change with the nonce", c.name)
+		}
+	}
+}
+
+func TestDynamicFeeTransactionEIP1559(t *testing.T) {
+	to := ethereum.Address{}
+	copy(to[:], bytes.Repeat([]byte{0x35}, ethereum.AddressLen))
+	accessed, err := ethereum.ParseAddress(gethTestAddr)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	tx := &ethereum.Transaction{
+		Type:      ethereum.DynamicFeeTxType,
+		ChainID:   big.NewInt(1),
+		Nonce:     9,
+		GasTipCap: big.NewInt(2000000000),
+		GasFeeCap: big.NewInt(40000000000),
+		Gas:       60000,
+		To:        &to,
+		Value:     new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil),
+		Data:      []byte{0x55, 0x44},
+		AccessList: []ethereum.AccessTuple{
+			{Address: accessed, StorageKeys: [][32]byte{{31: 0x01}, {31: 0x02}}},
+		},
+	}
+
+	hash, err := tx.SigningHash()
+	if nil != err {
+		t.Fatal(err)
+	}
+	wantData, _ := hex.DecodeString(eip1559SigningData)
+	if got := hex.EncodeToString(hash); got != hex.EncodeToString(ethereum.Keccak256(wantData)) ||
+		got != eip1559SigningHash {
+		t.Fatalf("invalid signing hash: got %s, want %s", got, eip1559SigningHash)
+	}
+
+	priv := privateKeyFromHex(t, eip155Key)
+	if err := tx.Sign(priv); nil != err {
+		t.Fatal(err)
+	}
+	if (0 != tx.V.Sign()) || (eip1559R != hex.EncodeToString(tx.R.Bytes())) ||
+		(eip1559S != hex.EncodeToString(tx.S.Bytes())) {
+		t.Fatalf("invalid signature: got (%v,%x,%x)", tx.V, tx.R, tx.S)
+	}
+
+	data, err := tx.MarshalBinary()
+	if nil != err {
+		t.Fatal(err)
+	} else if got := hex.EncodeToString(data); got != eip1559SignedTx {
+		t.Fatalf("invalid encoding: got %s, want %s", got, eip1559SignedTx)
+	}
+
+	decoded := new(ethereum.Transaction)
+	if err := decoded.UnmarshalBinary(data); nil != err {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(normalize(decoded), normalize(tx)) {
+		t.Fatalf("invalid decoding: got %+v, want %+v", decoded, tx)
+	}
+
+	sender, err := decoded.Sender()
+	if nil != err {
+		t.Fatal(err)
+	} else if want, _ := ethereum.ParseAddress(eip1559Sender); sender != want {
+		t.Fatalf("invalid sender: got %s, want %s", sender, want)
+	}
+}
+
+func TestDynamicFeeTransactionFields(t *testing.T) {
+	to := ethereum.Address{0xaa}
+	tx := &ethereum.Transaction{
+		Type: ethereum.DynamicFeeTxType, ChainID: big.NewInt(1), Nonce: 1,
+		GasTipCap: big.NewInt(2), GasFeeCap: big.NewInt(3), Gas: 4, To: &to,
+		Value: big.NewInt(5), Data: []byte{0x06},
+	}
+
+	// 0x02 || RLP([chainId, nonce, tip, feeCap, gas, to, value, data, accessList])
+	fields, err := rlp.Encode([]interface{}{uint64(1), uint64(1), uint64(2), uint64(3),
+		uint64(4), to[:], uint64(5), []byte{0x06}, []interface{}{}})
+	if nil != err {
+		t.Fatal(err)
+	}
+	want := ethereum.Keccak256([]byte{0x02}, fields)
+
+	if got, err := tx.SigningHash(); nil != err {
+		t.Fatal(err)
+	} else if !bytes.Equal(got, want) {
+		t.Fatalf("invalid signing hash: got %x, want %x", got, want)
+	}
+}
+
+func TestTransactionInvalid(t *testing.T) {
+	signed, _ := hex.DecodeString(gethEIP2718Encoded)
+
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"unknown type", append([]byte{0x05}, signed[1:]...)},
+		{"explicit legacy type", append([]byte{0x00}, signed[1:]...)},
+		{"truncated", signed[:len(signed)-1]},
+		{"wrong type for fields", append([]byte{0x02}, signed[1:]...)},
+	}
+
+	for _, c := range testCases {
+		if nil == new(ethereum.Transaction).UnmarshalBinary(c.data) {
+			t.Errorf("%s: decoding should fail", c.name)
+		}
+	}
+
+	// a high s is rejected since Homestead
+	tx := new(ethereum.Transaction)
+	if err := tx.UnmarshalBinary(signed); nil != err {
+		t.Fatal(err)
+	}
+	tx.S.Sub(elliptic.P256k1().Params().N, tx.S)
+	tx.V.Xor(tx.V, big.NewInt(1))
+	if _, err := tx.Sender(); nil == err {
+		t.Fatal("the high s should be rejected")
+	}
+
+	if _, err := new(ethereum.Transaction).Sender(); nil == err {
+		t.Fatal("the unsigned transaction has no sender")
+	}
+}
+
+// normalize replaces nil big integers and slices with zero values, as
+// decoding never outputs nil
+func normalize(tx *ethereum.Transaction) ethereum.Transaction {
+	out := *tx
+	for _, x := range []**big.Int{&out.ChainID, &out.GasPrice, &out.GasTipCap,
+		&out.GasFeeCap, &out.Value} {
+		if nil == *x {
+			*x = new(big.Int)
+		}
+	}
+	if nil == out.Data {
+		out.Data = []byte{}
+	}
+	if 0 == len(out.AccessList) {
+		out.AccessList = nil
+	}
+	for i := range out.AccessList {
+		if 0 == len(out.AccessList[i].StorageKeys) {
+			out.AccessList[i].StorageKeys = nil
+		}
+	}
+
+	return out
+}
+
+func privateKeyFromHex(t *testing.T, d string) *ecdsa.PrivateKey {
+	D, ok := new(big.Int).SetString(d, 16)
+	if !ok {
+		t.Fatalf("invalid private key %s", d)
+	}
+
+	priv, err := ecdsa.NewPrivateKey(elliptic.P256k1(), D)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	return priv
+}
@@ -0,0 +1,372 @@
+package ethereum
+
+// References:
+//   [EIP712]: Typed structured data hashing and signing,
+//     https://eips.ethereum.org/EIPS/eip-712
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sammy00/crypto/ecdsa"
+)
+
+// domainType is the name of the struct type of the domain
+const domainType = "EIP712Domain"
+
+// TypedDataField is a member of a struct type
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedData is the JSON document signed by eth_signTypedData_v4, where the
+// struct types, including EIP712Domain, are listed under Types
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      map[string]interface{}      `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+// ParseTypedData decodes the JSON typed-data document, with the numbers
+// kept exact for integers up to 256 bits
+func ParseTypedData(data []byte) (*TypedData, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	td := new(TypedData)
+	if err := d.Decode(td); nil != err {
+		return nil, err
+	}
+
+	return td, nil
+}
+
+// EncodeType encodes the struct type as section "Definition of encodeType"
+// of [EIP712] specifies, e.g.,
+//
+//	Mail(Person from,Person to,string contents)Person(string name,address wallet)
+//
+// where the referenced struct types follow the primary one in alphabetical
+// order.
+func (td *TypedData) EncodeType(name string) (string, error) {
+	deps := make(map[string]bool)
+	if err := td.collectTypes(name, deps); nil != err {
+		return "", err
+	}
+	delete(deps, name)
+
+	names := make([]string, 0, len(deps))
+	for dep := range deps {
+		names = append(names, dep)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, typ := range append([]string{name}, names...) {
+		b.WriteString(typ)
+		b.WriteByte('(')
+		for i, field := range td.Types[typ] {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(field.Type)
+			b.WriteByte(' ')
+			b.WriteString(field.Name)
+		}
+		b.WriteByte(')')
+	}
+
+	return b.String(), nil
+}
+
+// collectTypes adds the struct type and those it references recursively
+// into deps
+func (td *TypedData) collectTypes(name string, deps map[string]bool) error {
+	fields, ok := td.Types[name]
+	if !ok {
+		return fmt.Errorf("ethereum: undefined struct type %s", name)
+	}
+
+	deps[name] = true
+	for _, field := range fields {
+		typ := elementType(field.Type)
+		if _, ok := td.Types[typ]; ok && !deps[typ] {
+			if err := td.collectTypes(typ, deps); nil != err {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// TypeHash computes the Keccak-256 of EncodeType(name)
+func (td *TypedData) TypeHash(name string) ([]byte, error) {
+	typ, err := td.EncodeType(name)
+	if nil != err {
+		return nil, err
+	}
+
+	return Keccak256([]byte(typ)), nil
+}
+
+// HashStruct computes Keccak256(TypeHash(name) || encodeData(data)) as
+// section "Definition of hashStruct" of [EIP712] specifies. Every member of
+// the struct type must be present in data.
+func (td *TypedData) HashStruct(name string, data map[string]interface{}) ([]byte, error) {
+	typeHash, err := td.TypeHash(name)
+	if nil != err {
+		return nil, err
+	}
+
+	enc := append(make([]byte, 0, 32*(1+len(td.Types[name]))), typeHash...)
+	for _, field := range td.Types[name] {
+		v, ok := data[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("ethereum: missing member %s of %s", field.Name, name)
+		}
+
+		word, err := td.encodeValue(field.Type, v)
+		if nil != err {
+			return nil, fmt.Errorf("ethereum: invalid member %s of %s: %v", field.Name, name, err)
+		}
+		enc = append(enc, word...)
+	}
+
+	return Keccak256(enc), nil
+}
+
+// DomainSeparator computes the hashStruct of the domain
+func (td *TypedData) DomainSeparator() ([]byte, error) {
+	return td.HashStruct(domainType, td.Domain)
+}
+
+// Hash computes the hash to sign, i.e., the version 0x01 of EIP-191 as
+//
+//	Keccak256("\x19\x01" || DomainSeparator() || HashStruct(PrimaryType, Message))
+func (td *TypedData) Hash() ([]byte, error) {
+	domain, err := td.DomainSeparator()
+	if nil != err {
+		return nil, err
+	}
+
+	msg, err := td.HashStruct(td.PrimaryType, td.Message)
+	if nil != err {
+		return nil, err
+	}
+
+	return Keccak256([]byte{0x19, 0x01}, domain, msg), nil
+}
+
+// SignTypedData signs the typed data as eth_signTypedData_v4 does
+func SignTypedData(priv *ecdsa.PrivateKey, td *TypedData) ([]byte, error) {
+	hash, err := td.Hash()
+	if nil != err {
+		return nil, err
+	}
+
+	return Sign(priv, hash)
+}
+
+// VerifyTypedData checks the signature of the typed data is made by the
+// address. An error is returned if the typed data can't be hashed.
+func VerifyTypedData(address Address, td *TypedData, sig []byte) (bool, error) {
+	hash, err := td.Hash()
+	if nil != err {
+		return false, err
+	}
+
+	return verifySignature(address, hash, sig), nil
+}
+
+// elementType strips all the array suffixes off the type
+func elementType(typ string) string {
+	if i := strings.IndexByte(typ, '['); i >= 0 {
+		return typ[:i]
+	}
+
+	return typ
+}
+
+// encodeValue encodes the value of the given type into 32 bytes as section
+// "Definition of encodeData" of [EIP712] specifies
+func (td *TypedData) encodeValue(typ string, v interface{}) ([]byte, error) {
+	if strings.HasSuffix(typ, "]") {
+		return td.encodeArray(typ, v)
+	}
+
+	if _, ok := td.Types[typ]; ok {
+		data, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%v isn't a struct", v)
+		}
+
+		return td.HashStruct(typ, data)
+	}
+
+	switch {
+	case "string" == typ:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%v isn't a string", v)
+		}
+		return Keccak256([]byte(s)), nil
+	case "bytes" == typ:
+		b, err := decodeHexValue(v)
+		if nil != err {
+			return nil, err
+		}
+		return Keccak256(b), nil
+	case "bool" == typ:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%v isn't a bool", v)
+		}
+		word := make([]byte, 32)
+		if b {
+			word[31] = 1
+		}
+		return word, nil
+	case "address" == typ:
+		b, err := decodeHexValue(v)
+		if nil != err {
+			return nil, err
+		} else if AddressLen != len(b) {
+			return nil, fmt.Errorf("%v isn't an address", v)
+		}
+		return leftPad32(b), nil
+	case strings.HasPrefix(typ, "bytes"):
+		n, err := strconv.Atoi(typ[len("bytes"):])
+		if (nil != err) || (n < 1) || (n > 32) {
+			return nil, fmt.Errorf("unknown type %s", typ)
+		}
+		b, err := decodeHexValue(v)
+		if nil != err {
+			return nil, err
+		} else if len(b) > n {
+			return nil, fmt.Errorf("%v is longer than %d bytes", v, n)
+		}
+		word := make([]byte, 32)
+		copy(word, b)
+		return word, nil
+	case strings.HasPrefix(typ, "uint"):
+		return encodeInteger(typ[len("uint"):], false, v)
+	case strings.HasPrefix(typ, "int"):
+		return encodeInteger(typ[len("int"):], true, v)
+	}
+
+	return nil, fmt.Errorf("unknown type %s", typ)
+}
+
+// encodeArray encodes the array as the Keccak-256 of the concatenated
+// encodings of its elements
+func (td *TypedData) encodeArray(typ string, v interface{}) ([]byte, error) {
+	i := strings.LastIndexByte(typ, '[')
+	if i < 0 {
+		return nil, fmt.Errorf("unknown type %s", typ)
+	}
+
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%v isn't an array", v)
+	}
+	if size := typ[i+1 : len(typ)-1]; "" != size {
+		n, err := strconv.Atoi(size)
+		if nil != err {
+			return nil, fmt.Errorf("unknown type %s", typ)
+		} else if n != len(items) {
+			return nil, fmt.Errorf("the array should have %d elements", n)
+		}
+	}
+
+	enc := make([]byte, 0, 32*len(items))
+	for _, item := range items {
+		word, err := td.encodeValue(typ[:i], item)
+		if nil != err {
+			return nil, err
+		}
+		enc = append(enc, word...)
+	}
+
+	return Keccak256(enc), nil
+}
+
+// encodeInteger encodes the (un)signed integer of the given bit size, which
+// may be a JSON number, or a decimal or 0x-prefixed hex string
+func encodeInteger(bitSize string, signed bool, v interface{}) ([]byte, error) {
+	bits, err := strconv.Atoi(bitSize)
+	if (nil != err) || (bits < 8) || (bits > 256) || (0 != bits%8) {
+		return nil, fmt.Errorf("unknown integer size %s", bitSize)
+	}
+
+	var s string
+	switch vv := v.(type) {
+	case json.Number:
+		s = vv.String()
+	case string:
+		s = vv
+	case float64:
+		s = strconv.FormatFloat(vv, 'f', -1, 64)
+	default:
+		return nil, fmt.Errorf("%v isn't an integer", v)
+	}
+
+	x, ok := new(big.Int), false
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		x, ok = x.SetString(s[2:], 16)
+	} else {
+		x, ok = x.SetString(s, 10)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%v isn't an integer", v)
+	}
+
+	// the range is [0,2^bits) if unsigned, or [-2^(bits-1),2^(bits-1))
+	min, max := new(big.Int), new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	if signed {
+		max.Rsh(max, 1)
+		min.Neg(max)
+	}
+	if (x.Cmp(min) < 0) || (x.Cmp(max) >= 0) {
+		return nil, fmt.Errorf("%v overflows %d bits", v, bits)
+	}
+
+	// two's complement over 256 bits
+	if x.Sign() < 0 {
+		x.Add(x, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+
+	return leftPad32(x.Bytes()), nil
+}
+
+// decodeHexValue decodes the 0x-prefixed hex string
+func decodeHexValue(v interface{}) ([]byte, error) {
+	s, ok := v.(string)
+	if !ok || (!strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X")) {
+		return nil, fmt.Errorf("%v isn't a 0x-prefixed hex string", v)
+	}
+
+	b, err := hex.DecodeString(s[2:])
+	if nil != err {
+		return nil, errors.New("invalid hex string " + s)
+	}
+
+	return b, nil
+}
+
+// leftPad32 pads b with leading zeros into 32 bytes
+func leftPad32(b []byte) []byte {
+	word := make([]byte, 32)
+	copy(word[32-len(b):], b)
+
+	return word
+}
@@ -0,0 +1,90 @@
+package ethereum_test
+
+// mailTypedData is the example of EIP-712, signed by Keccak256("cow"), whose
+// address is 0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826
+const mailTypedData = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {
+      "name": "Cow",
+      "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"
+    },
+    "to": {
+      "name": "Bob",
+      "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"
+    },
+    "contents": "Hello, Bob!"
+  }
+}`
+
+// the intermediate values of the example of EIP-712
+const (
+	mailEncodeType      = "Mail(Person from,Person to,string contents)Person(string name,address wallet)"
+	mailTypeHash        = "a0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2"
+	mailHashStruct      = "c52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e"
+	mailDomainSeparator = "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"
+	mailHash            = "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"
+	mailSignature       = "4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d" +
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562" + "1c"
+	mailSigner = "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"
+)
+
+// groupTypedData exercises nested structs, arrays and the other atomic types
+const groupTypedData = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "chainId", "type": "uint256"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallets", "type": "address[]"}
+    ],
+    "Group": [
+      {"name": "name", "type": "string"},
+      {"name": "members", "type": "Person[2]"},
+      {"name": "active", "type": "bool"},
+      {"name": "balance", "type": "int64"},
+      {"name": "tag", "type": "bytes4"},
+      {"name": "data", "type": "bytes"},
+      {"name": "matrix", "type": "uint8[][]"}
+    ]
+  },
+  "primaryType": "Group",
+  "domain": {"name": "Groups", "chainId": "0x05"},
+  "message": {
+    "name": "Cows",
+    "members": [
+      {"name": "Cow", "wallets": ["0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"]},
+      {"name": "Bob", "wallets": []}
+    ],
+    "active": true,
+    "balance": -2,
+    "tag": "0xdeadbeef",
+    "data": "0x0102",
+    "matrix": [[1, 2], ["3"]]
+  }
+}`
@@ -0,0 +1,174 @@
+package ethereum_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/ethereum"
+)
+
+func TestTypedDataMail(t *testing.T) {
+	td, err := ethereum.ParseTypedData([]byte(mailTypedData))
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	if got, err := td.EncodeType("Mail"); nil != err {
+		t.Fatal(err)
+	} else if got != mailEncodeType {
+		t.Fatalf("invalid type encoding: got %s, want %s", got, mailEncodeType)
+	}
+
+	typeHash, err := td.TypeHash("Mail")
+	if nil != err {
+		t.Fatal(err)
+	}
+	hashStruct, err := td.HashStruct("Mail", td.Message)
+	if nil != err {
+		t.Fatal(err)
+	}
+	domain, err := td.DomainSeparator()
+	if nil != err {
+		t.Fatal(err)
+	}
+	hash, err := td.Hash()
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name      string
+		got, want string
+	}{
+		{"typeHash", hex.EncodeToString(typeHash), mailTypeHash},
+		{"hashStruct", hex.EncodeToString(hashStruct), mailHashStruct},
+		{"domainSeparator", hex.EncodeToString(domain), mailDomainSeparator},
+		{"hash", hex.EncodeToString(hash), mailHash},
+	}
+	for _, c := range testCases {
+		if c.got != c.want {
+			t.Fatalf("invalid %s: got %s, want %s", c.name, c.got, c.want)
+		}
+	}
+
+	priv, err := ecdsa.NewPrivateKey(elliptic.P256k1(),
+		new(big.Int).SetBytes(ethereum.Keccak256([]byte("cow"))))
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	sig, err := ethereum.SignTypedData(priv, td)
+	if nil != err {
+		t.Fatal(err)
+	} else if got := hex.EncodeToString(sig); got != mailSignature {
+		t.Fatalf("invalid signature: got %s, want %s", got, mailSignature)
+	}
+
+	signer, err := ethereum.ParseAddress(mailSigner)
+	if nil != err {
+		t.Fatal(err)
+	} else if signer != addressOf(t, &priv.PublicKey) {
+		t.Fatalf("invalid signer: got %s, want %s", addressOf(t, &priv.PublicKey), signer)
+	}
+	if ok, err := ethereum.VerifyTypedData(signer, td, sig); nil != err {
+		t.Fatal(err)
+	} else if !ok {
+		t.Fatal("the signature should be valid")
+	}
+
+	// any change of the message invalidates the signature
+	td.Message["contents"] = "Hello, Alice!"
+	if ok, err := ethereum.VerifyTypedData(signer, td, sig); (nil != err) || ok {
+		t.Fatalf("the signature should be invalid: got (%v,%v)", ok, err)
+	}
+}
+
+func TestTypedDataNested(t *testing.T) {
+	td, err := ethereum.ParseTypedData([]byte(groupTypedData))
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	const encodeType = "Group(string name,Person[2] members,bool active,int64 balance," +
+		"bytes4 tag,bytes data,uint8[][] matrix)Person(string name,address[] wallets)"
+	if got, err := td.EncodeType("Group"); nil != err {
+		t.Fatal(err)
+	} else if got != encodeType {
+		t.Fatalf("invalid type encoding: got %s, want %s", got, encodeType)
+	}
+
+	// assemble the hash by hand
+	word := func(hexStr string) []byte {
+		b, _ := hex.DecodeString(hexStr)
+		return append(make([]byte, 32-len(b)), b...)
+	}
+	keccak := ethereum.Keccak256
+
+	personType := keccak([]byte("Person(string name,address[] wallets)"))
+	cow := keccak(personType, keccak([]byte("Cow")),
+		keccak(word("cd2a3d9f938e13cd947ec05abc7fe734df8dd826")))
+	bob := keccak(personType, keccak([]byte("Bob")), keccak())
+
+	tag := make([]byte, 32)
+	copy(tag, []byte{0xde, 0xad, 0xbe, 0xef})
+
+	group := keccak(
+		keccak([]byte(encodeType)),
+		keccak([]byte("Cows")),
+		keccak(cow, bob),
+		word("01"),
+		bytes.Repeat([]byte{0xff}, 31), []byte{0xfe},
+		tag,
+		keccak([]byte{0x01, 0x02}),
+		keccak(keccak(word("01"), word("02")), keccak(word("03"))),
+	)
+
+	domain := keccak(keccak([]byte("EIP712Domain(string name,uint256 chainId)")),
+		keccak([]byte("Groups")), word("05"))
+
+	want := keccak([]byte{0x19, 0x01}, domain, group)
+
+	if got, err := td.Hash(); nil != err {
+		t.Fatal(err)
+	} else if !bytes.Equal(got, want) {
+		t.Fatalf("invalid hash: got %x, want %x", got, want)
+	}
+}
+
+func TestTypedDataInvalid(t *testing.T) {
+	testCases := []struct {
+		name       string
+		old, value string
+	}{
+		{"undefined type", `"type": "bytes"`, `"type": "Foo"`},
+		{"uint8 overflow", `[1, 2]`, `[256, 2]`},
+		{"int64 overflow", `-2`, `-9223372036854775809`},
+		{"bytes4 overflow", `"0xdeadbeef"`, `"0xdeadbeef00"`},
+		{"wrong fixed size", `{"name": "Bob", "wallets": []}`, `{"name": "Bob", "wallets": []}, {"name": "Eve", "wallets": []}`},
+		{"short address", `["0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"]`, `["0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD8"]`},
+		{"missing member", `"active": true,`, ``},
+		{"bool as string", `"active": true`, `"active": "true"`},
+		{"bytes without 0x", `"0x0102"`, `"0102"`},
+		{"unknown type", `"type": "bool"`, `"type": "boolean"`},
+	}
+
+	for _, c := range testCases {
+		doc := strings.Replace(groupTypedData, c.old, c.value, 1)
+		if doc == groupTypedData {
+			t.Fatalf("%s: the document isn't modified", c.name)
+		}
+
+		td, err := ethereum.ParseTypedData([]byte(doc))
+		if nil != err {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if _, err := td.Hash(); nil == err {
+			t.Errorf("%s: hashing should fail", c.name)
+		}
+	}
+}
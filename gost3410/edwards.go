@@ -0,0 +1,122 @@
+package gost3410
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/sammy00/crypto/elliptic"
+)
+
+// ErrNotEdwards is returned if the curve has no twisted Edwards form known
+// to this package, or the point has no image under the birational map
+var ErrNotEdwards = errors.New("gost3410: no twisted Edwards form for the point")
+
+// edwardsParams is the twisted Edwards form eu^2+v^2 = 1+du^2v^2 of a curve,
+// along with the constants s = (e-d)/4 and t = (e+d)/6 of the map
+type edwardsParams struct {
+	e, d, s, t *big.Int
+}
+
+var (
+	// edwardsInitOncer serves for one-time-only initialization of edwards
+	edwardsInitOncer sync.Once
+	// edwards maps the curves to their twisted Edwards forms by [RFC7836]
+	edwards map[elliptic.Curve]*edwardsParams
+)
+
+func initEdwards() {
+	edwards = map[elliptic.Curve]*edwardsParams{
+		elliptic.GOST256A(): newEdwardsParams(elliptic.GOST256A(), "01",
+			"0605F6B7C183FA81578BC39CFAD518132B9DF62897009AF7E522C32D6DC7BFFB"),
+		elliptic.GOST512C(): newEdwardsParams(elliptic.GOST512C(), "01",
+			"9E4F5D8C017D8D9F13A5CF3CDF5BFE4DAB402D54198E31EBDE28A0621050439C"+
+				"A6B39E0A515C06B304E2CE43E79E369E91A0CFC2BC2A22B4CA302DBB33EE7550"),
+	}
+}
+
+func newEdwardsParams(c elliptic.Curve, e, d string) *edwardsParams {
+	P := c.Params().P
+
+	params := new(edwardsParams)
+	params.e, _ = new(big.Int).SetString(e, 16)
+	params.d, _ = new(big.Int).SetString(d, 16)
+
+	params.s = new(big.Int).Sub(params.e, params.d)
+	params.s.Mul(params.s, new(big.Int).ModInverse(big.NewInt(4), P))
+	params.s.Mod(params.s, P)
+
+	params.t = new(big.Int).Add(params.e, params.d)
+	params.t.Mul(params.t, new(big.Int).ModInverse(big.NewInt(6), P))
+	params.t.Mod(params.t, P)
+
+	return params
+}
+
+// FromEdwards maps the point (u,v) on the twisted Edwards form of the curve,
+// which is either elliptic.GOST256A() or elliptic.GOST512C(), to (x,y) in the
+// Weierstrass form, by x = s(1+v)/(1-v)+t and y = s(1+v)/((1-v)u)
+func FromEdwards(c elliptic.Curve, u, v *big.Int) (x, y *big.Int, err error) {
+	edwardsInitOncer.Do(initEdwards)
+	params, ok := edwards[c]
+	if !ok {
+		return nil, nil, ErrNotEdwards
+	}
+	P := c.Params().P
+
+	// 1/(1-v)
+	oneMinusVInv := new(big.Int).Sub(big.NewInt(1), v)
+	oneMinusVInv.Mod(oneMinusVInv, P)
+	if (0 == u.Sign()) || (0 == oneMinusVInv.Sign()) {
+		return nil, nil, ErrNotEdwards
+	}
+	oneMinusVInv.ModInverse(oneMinusVInv, P)
+
+	// w = s(1+v)/(1-v)
+	w := new(big.Int).Add(big.NewInt(1), v)
+	w.Mul(w, params.s)
+	w.Mul(w, oneMinusVInv)
+	w.Mod(w, P)
+
+	x = new(big.Int).Add(w, params.t)
+	x.Mod(x, P)
+
+	y = new(big.Int).ModInverse(u, P)
+	y.Mul(y, w)
+	y.Mod(y, P)
+
+	return x, y, nil
+}
+
+// ToEdwards maps the point (x,y) in the Weierstrass form of the curve, which
+// is either elliptic.GOST256A() or elliptic.GOST512C(), to (u,v) on the
+// twisted Edwards form, by u = (x-t)/y and v = (x-t-s)/(x-t+s)
+func ToEdwards(c elliptic.Curve, x, y *big.Int) (u, v *big.Int, err error) {
+	edwardsInitOncer.Do(initEdwards)
+	params, ok := edwards[c]
+	if !ok {
+		return nil, nil, ErrNotEdwards
+	}
+	P := c.Params().P
+
+	// x-t
+	xMinusT := new(big.Int).Sub(x, params.t)
+	xMinusT.Mod(xMinusT, P)
+
+	// x-t+s
+	denominator := new(big.Int).Add(xMinusT, params.s)
+	denominator.Mod(denominator, P)
+	if (0 == y.Sign()) || (0 == denominator.Sign()) {
+		return nil, nil, ErrNotEdwards
+	}
+
+	u = new(big.Int).ModInverse(y, P)
+	u.Mul(u, xMinusT)
+	u.Mod(u, P)
+
+	v = new(big.Int).Sub(xMinusT, params.s)
+	v.Mul(v, denominator.ModInverse(denominator, P))
+	v.Mod(v, P)
+
+	return u, v, nil
+}
@@ -0,0 +1,54 @@
+package gost3410_test
+
+import (
+	"testing"
+
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/gost3410"
+)
+
+func TestEdwards(t *testing.T) {
+	// the base points in the twisted Edwards form given by [RFC7836]
+	testCases := []struct {
+		curve elliptic.Curve
+		u, v  string
+	}{
+		{
+			elliptic.GOST256A(),
+			"0D",
+			"60CA1E32AA475B348488C38FAB07649CE7EF8DBE87F22E81F92B2592DBA300E7",
+		},
+		{
+			elliptic.GOST512C(),
+			"12",
+			"469AF79D1FB1F5E16B99592B77A01E2A0FDFB0D01794368D9A56117F7B386695" +
+				"22DD4B650CF789EEBF068C5D139732F0905622C04B2BAAE7600303EE73001A3D",
+		},
+	}
+
+	for _, c := range testCases {
+		params := c.curve.Params()
+
+		x, y, err := gost3410.FromEdwards(c.curve, mustBigInt(c.u), mustBigInt(c.v))
+		if nil != err {
+			t.Fatal(err)
+		}
+		if (0 != x.Cmp(params.Gx)) || (0 != y.Cmp(params.Gy)) {
+			t.Fatalf("%s: invalid base point: got (%x,%x)", params.Name, x, y)
+		}
+
+		u, v, err := gost3410.ToEdwards(c.curve, params.Gx, params.Gy)
+		if nil != err {
+			t.Fatal(err)
+		}
+		if (0 != u.Cmp(mustBigInt(c.u))) || (0 != v.Cmp(mustBigInt(c.v))) {
+			t.Fatalf("%s: invalid Edwards point: got (%x,%x), want (%s,%s)", params.Name, u, v, c.u, c.v)
+		}
+	}
+
+	// no Edwards form for curves out of RFC7836 paramSetA and paramSetC
+	G := elliptic.GOST256B().Params()
+	if _, _, err := gost3410.ToEdwards(elliptic.GOST256B(), G.Gx, G.Gy); gost3410.ErrNotEdwards != err {
+		t.Fatalf("invalid error: got %v, want %v", err, gost3410.ErrNotEdwards)
+	}
+}
@@ -0,0 +1,5 @@
+package gost3410
+
+// SignWithNonce exposes signWithNonce to the tests, which inject the nonces
+// of the test vectors
+var SignWithNonce = signWithNonce
@@ -0,0 +1,199 @@
+// Package gost3410 implements the digital signature of GOST R 34.10-2012
+// with the Streebog hash of GOST R 34.11-2012, over the curves of this
+// library such as the ones by elliptic.GOST256A() and elliptic.GOST512A(). The
+// keys are the ones of the ecdsa package.
+package gost3410
+
+// References:
+//   [RFC7091]: GOST R 34.10-2012: Digital Signature Algorithm,
+//     https://tools.ietf.org/html/rfc7091
+//   [RFC7836]: Guidelines on the Cryptographic Algorithms to Accompany the
+//     Usage of Standards GOST R 34.10-2012 and GOST R 34.11-2012,
+//     https://tools.ietf.org/html/rfc7836
+//   [RFC4491]: Using the GOST R 34.10-94, GOST R 34.10-2001, and GOST R
+//     34.11-94 Algorithms with the Internet X.509 Public Key Infrastructure
+//     Certificate and CRL Profile, https://tools.ietf.org/html/rfc4491
+//   [RFC6979]: Deterministic Usage of the Digital Signature Algorithm (DSA)
+//     and Elliptic Curve Digital Signature Algorithm (ECDSA),
+//     https://tools.ietf.org/html/rfc6979
+
+import (
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/misc"
+	"github.com/sammy00/crypto/streebog"
+)
+
+var (
+	// ErrInvalidPrivateKey is returned if the private key is out of [1,N-1]
+	ErrInvalidPrivateKey = errors.New("gost3410: invalid private key")
+	// ErrInvalidSignature is returned if the encoded signature is malformed
+	ErrInvalidSignature = errors.New("gost3410: invalid signature")
+)
+
+// NewHash returns the Streebog hash matching the curve, which is
+// Streebog-256 for curves of order up to 256 bits and Streebog-512 otherwise
+func NewHash(c elliptic.Curve) hash.Hash {
+	if c.Params().N.BitLen() <= 256 {
+		return streebog.New256()
+	}
+
+	return streebog.New512()
+}
+
+// Sign hashes the message with the Streebog matching the curve of priv, and
+// signs the digest as SignDigest does
+func Sign(rand io.Reader, priv *ecdsa.PrivateKey, msg []byte) (r, s *big.Int, err error) {
+	h := NewHash(priv.Curve)
+	h.Write(msg)
+
+	return SignDigest(rand, priv, h.Sum(nil))
+}
+
+// SignDigest signs the digest by section 6.1 of [RFC7091]. Following the
+// convention of Streebog, the digest is taken as the little-endian encoding
+// of the integer alpha. The nonce k is derived with the HMAC-DRBG of
+// [RFC6979] over the Streebog of NewHash from the private key, the digest
+// and as many bytes read from rand as the hash outputs, so that it stays
+// secret even if rand is broken.
+func SignDigest(rand io.Reader, priv *ecdsa.PrivateKey, digest []byte) (r, s *big.Int, err error) {
+	c := priv.Curve
+	if (nil == priv.D) || (priv.D.Sign() <= 0) || (priv.D.Cmp(c.Params().N) >= 0) {
+		return nil, nil, ErrInvalidPrivateKey
+	}
+
+	newHash := func() hash.Hash { return NewHash(c) }
+
+	extra := make([]byte, newHash().Size())
+	if _, err := io.ReadFull(rand, extra); nil != err {
+		return nil, nil, err
+	}
+
+	g := ecdsa.NewNonceRFC6979(c, priv.D, digest, newHash, extra)
+	defer g.Wipe()
+
+	return signWithNonce(priv, digest, g.Next)
+}
+
+// signWithNonce runs steps 2 to 6 of section 6.1 of [RFC7091] with the
+// nonces k drawn from next: a k giving r = 0 or s = r*d+k*e = 0 mod N is
+// dropped for the next one. SignDigest has checked d already.
+func signWithNonce(priv *ecdsa.PrivateKey, digest []byte,
+	next func() (*big.Int, error)) (r, s *big.Int, err error) {
+	c := priv.Curve
+	N := c.Params().N
+
+	e := digestToInt(digest, N)
+	for {
+		k, err := next()
+		if nil != err {
+			return nil, nil, err
+		}
+
+		// r = x mod N, where (x,y) = k*G
+		r, _ = c.ScalarBaseMult(k.Bytes())
+		r.Mod(r, N)
+		if 0 == r.Sign() {
+			continue
+		}
+
+		// s = (r*d+k*e) mod N
+		s = new(big.Int).Mul(r, priv.D)
+		s.Add(s, new(big.Int).Mul(k, e))
+		s.Mod(s, N)
+		if 0 != s.Sign() {
+			return r, s, nil
+		}
+	}
+}
+
+// Verify hashes the message with the Streebog matching the curve of pub,
+// and verifies the signature (r,s) of the digest as VerifyDigest does
+func Verify(pub *ecdsa.PublicKey, msg []byte, r, s *big.Int) bool {
+	h := NewHash(pub.Curve)
+	h.Write(msg)
+
+	return VerifyDigest(pub, h.Sum(nil), r, s)
+}
+
+// VerifyDigest verifies the signature (r,s) of the digest by section 6.2 of
+// [RFC7091]
+func VerifyDigest(pub *ecdsa.PublicKey, digest []byte, r, s *big.Int) bool {
+	c := pub.Curve
+	N := c.Params().N
+
+	// ensure r,s in [1,n-1]
+	if (r.Sign() <= 0) || (s.Sign() <= 0) {
+		return false
+	}
+	if (r.Cmp(N) >= 0) || (s.Cmp(N) >= 0) {
+		return false
+	}
+
+	// v = e^{-1} mod N
+	v := digestToInt(digest, N)
+	v.ModInverse(v, N)
+
+	// z1 = s*v mod N, z2 = -r*v mod N
+	z1 := new(big.Int).Mul(s, v)
+	z1.Mod(z1, N)
+	z2 := new(big.Int).Mul(r, v)
+	z2.Neg(z2)
+	z2.Mod(z2, N)
+
+	// R = x mod N, where (x,y) = z1*G+z2*Q
+	x1, y1 := c.ScalarBaseMult(z1.Bytes())
+	x2, y2 := c.ScalarMult(pub.X, pub.Y, z2.Bytes())
+	x, _ := c.Add(x1, y1, x2, y2)
+	x.Mod(x, N)
+
+	return 0 == x.Cmp(r)
+}
+
+// Marshal encodes the signature as s||r in big endian, each of which is as
+// long as the order of the curve, as is specified by section 2.2.2 of
+// [RFC4491]
+func Marshal(c elliptic.Curve, r, s *big.Int) []byte {
+	byteLen := (c.Params().N.BitLen() + 7) >> 3
+
+	out := make([]byte, 2*byteLen)
+	misc.ReverseCopy(out[:byteLen], s.Bytes())
+	misc.ReverseCopy(out[byteLen:], r.Bytes())
+
+	return out
+}
+
+// Unmarshal decodes the signature encoded by Marshal
+func Unmarshal(c elliptic.Curve, sig []byte) (r, s *big.Int, err error) {
+	byteLen := (c.Params().N.BitLen() + 7) >> 3
+	if len(sig) != 2*byteLen {
+		return nil, nil, ErrInvalidSignature
+	}
+
+	s = new(big.Int).SetBytes(sig[:byteLen])
+	r = new(big.Int).SetBytes(sig[byteLen:])
+
+	return r, s, nil
+}
+
+// digestToInt computes e = alpha mod N, where alpha is the digest in little
+// endian, and e = 1 if it happens to be 0
+func digestToInt(digest []byte, N *big.Int) *big.Int {
+	alpha := make([]byte, len(digest))
+	for i, v := range digest {
+		alpha[len(digest)-1-i] = v
+	}
+
+	e := new(big.Int).SetBytes(alpha)
+	e.Mod(e, N)
+	if 0 == e.Sign() {
+		e.SetInt64(1)
+	}
+
+	return e
+}
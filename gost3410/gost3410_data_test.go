@@ -0,0 +1,117 @@
+package gost3410_test
+
+import (
+	"math/big"
+
+	"github.com/sammy00/crypto/elliptic"
+)
+
+// the example curves of [RFC7091]
+var (
+	testCurve256 = &elliptic.WeierstrassCurve{
+		CurveParams: &elliptic.CurveParams{
+			P:       mustBigInt("8000000000000000000000000000000000000000000000000000000000000431"),
+			N:       mustBigInt("8000000000000000000000000000000150FE8A1892976154C59CFC193ACCF5B3"),
+			B:       mustBigInt("5FBFF498AA938CE739B8E022FBAFEF40563F6E6A3472FC2A514C0CE9DAE23B7E"),
+			Gx:      mustBigInt("02"),
+			Gy:      mustBigInt("08E2A8A0E65147D4BD6316030E16D19C85C97F0A9CA267122B96ABBCEA7E8FC8"),
+			BitSize: 256,
+			Name:    "RFC7091 example curve 256",
+		},
+		A: big.NewInt(7),
+	}
+	testCurve512 = &elliptic.WeierstrassCurve{
+		CurveParams: &elliptic.CurveParams{
+			P: mustBigInt("4531ACD1FE0023C7550D267B6B2FEE80922B14B2FFB90F04D4EB7C09B5D2D15D" +
+				"F1D852741AF4704A0458047E80E4546D35B8336FAC224DD81664BBF528BE6373"),
+			N: mustBigInt("4531ACD1FE0023C7550D267B6B2FEE80922B14B2FFB90F04D4EB7C09B5D2D15D" +
+				"A82F2D7ECB1DBAC719905C5EECC423F1D86E25EDBE23C595D644AAF187E6E6DF"),
+			B: mustBigInt("1CFF0806A31116DA29D8CFA54E57EB748BC5F377E49400FDD788B649ECA1AC43" +
+				"61834013B2AD7322480A89CA58E0CF74BC9E540C2ADD6897FAD0A3084F302ADC"),
+			Gx: mustBigInt("24D19CC64572EE30F396BF6EBBFD7A6C5213B3B3D7057CC825F91093A68CD762" +
+				"FD60611262CD838DC6B60AA7EEE804E28BC849977FAC33B4B530F1B120248A9A"),
+			Gy: mustBigInt("2BB312A43BD2CE6E0D020613C857ACDDCFBF061E91E5F2C3F32447C259F39B2C" +
+				"83AB156D77F1496BF7EB3351E1EE4E43DC1A18B91B24640B6DBB92CB1ADD371E"),
+			BitSize: 512,
+			Name:    "RFC7091 example curve 512",
+		},
+		A: big.NewInt(7),
+	}
+)
+
+// the examples in appendix A of [RFC7091], where e is alpha mod N
+var rfc7091TestVec = []struct {
+	curve   elliptic.Curve
+	d, e, k string
+	xQ, yQ  string
+	r, s    string
+}{
+	{
+		testCurve256,
+		"7A929ADE789BB9BE10ED359DD39A72C11B60961F49397EEE1D19CE9891EC3B28",
+		"2DFBC1B372D89A1188C09C52E0EEC61FCE52032AB1022E8E67ECE6672B043EE5",
+		"77105C9B20BCD3122823C8CF6FCC7B956DE33814E95B7FE64FED924594DCEAB3",
+		"7F2B49E270DB6D90D8595BEC458B50C58585BA1D4E9B788F6689DBD8E56FD80B",
+		"26F1B489D6701DD185C8413A977B3CBBAF64D1C593D26627DFFB101A87FF77DA",
+		"41AA28D2F1AB148280CD9ED56FEDA41974053554A42767B83AD043FD39DC0493",
+		"01456C64BA4642A1653C235A98A60249BCD6D3F746B631DF928014F6C5BF9C40",
+	},
+	{
+		testCurve512,
+		"0BA6048AADAE241BA40936D47756D7C93091A0E8514669700EE7508E508B1020" +
+			"72E8123B2200A0563322DAD2827E2714A2636B7BFD18AADFC62967821FA18DD4",
+		"3754F3CFACC9E0615C4F4A7C4D8DAB531B09B6F9C170C533A71D147035B0C591" +
+			"7184EE536593F4414339976C647C5D5A407ADEDB1D560C4FC6777D2972075B8C",
+		"0359E7F4B1410FEACC570456C6801496946312120B39D019D455986E364F3658" +
+			"86748ED7A44B3E794434006011842286212273A6D14CF70EA3AF71BB1AE679F1",
+		"115DC5BC96760C7B48598D8AB9E740D4C4A85A65BE33C1815B5C320C854621DD" +
+			"5A515856D13314AF69BC5B924C8B4DDFF75C45415C1D9DD9DD33612CD530EFE1",
+		"37C7C90CD40B0F5621DC3AC1B751CFA0E2634FA0503B3D52639F5D7FB72AFD61" +
+			"EA199441D943FFE7F0C70A2759A3CDB84C114E1F9339FDF27F35ECA93677BEEC",
+		"2F86FA60A081091A23DD795E1E3C689EE512A3C82EE0DCC2643C78EEA8FCACD3" +
+			"5492558486B20F1C9EC197C90699850260C93BCBCD9C5C3317E19344E173AE36",
+		"1081B394696FFE8E6585E7A9362D26B6325F56778AADBC081C0BFBE933D52FF5" +
+			"823CE288E8C4F362526080DF7F70CE406A6EEB1F56919CB92A9853BDE73E5B4A",
+	},
+}
+
+// gcryptMessage is signed by libgcrypt over the parameter sets below
+const gcryptMessage = "GOST R 34.10-2012 signature test"
+
+// the signatures of gcryptMessage by libgcrypt, where Q is uncompressed
+var gcryptTestVec = []struct {
+	curve elliptic.Curve
+	Q     string
+	r, s  string
+}{
+	{
+		elliptic.GOST256A(),
+		"046d44ec38894d29881fe9929c23ed0a3fa6cb3ae77ca5b92434ad6d8a16851c786b13d09fe7b1ac9f8e2aa99a0790388d76b6616c6cbf397290252153c6cabdb9",
+		"21de9867ebb593dc310e8bc0b1a0b3a24a0eef4e16a8b59a7452e6309650dd63",
+		"30b87df0c3b42617590ac6a827a9342711c39da0a99820e28da0bb9a76d5fb59",
+	},
+	{
+		elliptic.GOST256B(),
+		"048d1e53e3e0f27ea955f5f9ce7a660a469fdae6e911ce8bf7d363808f7dd97fa45a163c423c0de40e4480aee52db0dc808fcce2baffb0881067a8c4f27cde5d68",
+		"0a059616a3846e53cd9b299cdf06c17b8cae406de1cdebf5f5e852943d96a950",
+		"2065164e965b37923e544c05931abf0c075f6745a26f9b0d4499ef249e74d2d6",
+	},
+	{
+		elliptic.GOST512A(),
+		"0424ae670eca96a9d9324a947cf407e2654cf5a3074b82585fc91f753787eb23204f557aefde409259f1f2428581257ac7633fb3b685ace6b05c6baf550fd6a427157764785b292703c225e6da342c1f873055c91721a26add567bc39b1e25f492026d7489dcb33efc95bc725ff872f125fba3f19c638a5cb1f4e65ae1709b0815",
+		"f1818bcd9a139e266e7f4b58baaeff1e2ce6b01526af5dd090d59fae720d5d559e3da42609a02366c025a3f0cf93bbb6660b9ff4ad26036a06a32737560900a1",
+		"570c1fe9b956d827beb0958586a21911253b8698af1e2d409f77b05624f62655dc1afb1b2330a5b7362e151a9c9e39d34c17e055e0498848cfe34e97e1c5e067",
+	},
+	{
+		elliptic.GOST512B(),
+		"0419b6da6ccc53fd3023d3d027f49e5ac844691ab1ec4733974aed74875e0166456b6c5f1170a03523dabfd4f3728533a58b8d55442fb8b39f4292d6772e29e46e00e5d3f1045833648062c578a3697c6d641054529478311239be10576c7e7df2ba862cbfc2363061b26ce3c90f2b15682b8dedfe47bff260ebd3f78cd7b7fd56",
+		"39bb8b14b34989e2208ec3e0d2012a9f618d40ea95665b3a5846dc3390aeccafd8ea43e3986cbea1bc13d0d95d125a102f7ee43547d80c469eb7d4a7b51b50c4",
+		"4dc678803f982d2df1d1308617fcd08a6a4a33e62d61dd2ff8a472449c170b5367c789574045d310050888147102898c9e3de6507309a5aea6a5d5ef159fb8ce",
+	},
+	{
+		elliptic.GOST512C(),
+		"0426898be3928ff2e746a0e71606587f417666d4443f0aee0ea47ae89f2453a491471988e36516e85e7f1cffaedfb2dc46b8e7b26a86e6ced4bc6f373cceca05f45d966717ca1701724033343b2bd28e9dd3705099d39ebd9d9f31bcc9a460c40d46f08f202b449f9c154249b98389de1ab3eb2398449e0c42b127f6497b72bb75",
+		"3e311656e08d452fa69567d92dee22eaf4a3083566528aedb7b27dc2088d5314d7de82f10060473e8a24e95121b33eaa4a2294788ff23cc9ec186b7d265ccc6a",
+		"055bd3dbb9ddc3aa81c6dc997bd2fcad07187ad1c0c793d4ab443182f50f4c7e487f19f291382bc5704db5b3f36ba90e795a70d26fd8543a5e311d4efcbcf984",
+	},
+}
@@ -0,0 +1,206 @@
+package gost3410_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"hash"
+	"math/big"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/gost3410"
+	"github.com/sammy00/crypto/streebog"
+)
+
+func mustBigInt(s string) *big.Int {
+	x, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex: " + s)
+	}
+
+	return x
+}
+
+// fixedNonce hands out k as the nonce of every attempt
+func fixedNonce(k *big.Int) func() (*big.Int, error) {
+	return func() (*big.Int, error) {
+		return new(big.Int).Set(k), nil
+	}
+}
+
+// littleEndian encodes x in little endian as long as the order of the curve,
+// which is how a Streebog digest carries alpha
+func littleEndian(c elliptic.Curve, x *big.Int) []byte {
+	out := make([]byte, (c.Params().N.BitLen()+7)/8)
+	for i, v := range x.Bytes() {
+		out[len(x.Bytes())-1-i] = v
+	}
+
+	return out
+}
+
+func TestSignDigestRFC7091(t *testing.T) {
+	for i, c := range rfc7091TestVec {
+		priv, err := ecdsa.NewPrivateKey(c.curve, mustBigInt(c.d))
+		if nil != err {
+			t.Fatal(err)
+		}
+		if (0 != priv.X.Cmp(mustBigInt(c.xQ))) || (0 != priv.Y.Cmp(mustBigInt(c.yQ))) {
+			t.Fatalf("#%d: invalid public key: got (%x,%x)", i, priv.X, priv.Y)
+		}
+
+		digest := littleEndian(c.curve, mustBigInt(c.e))
+		r, s, err := gost3410.SignWithNonce(priv, digest, fixedNonce(mustBigInt(c.k)))
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		if 0 != r.Cmp(mustBigInt(c.r)) {
+			t.Errorf("#%d: invalid r: got %x, want %s", i, r, c.r)
+		}
+		if 0 != s.Cmp(mustBigInt(c.s)) {
+			t.Errorf("#%d: invalid s: got %x, want %s", i, s, c.s)
+		}
+
+		if !gost3410.VerifyDigest(&priv.PublicKey, digest, r, s) {
+			t.Errorf("#%d: verification should pass", i)
+		}
+		if gost3410.VerifyDigest(&priv.PublicKey, digest, s, r) {
+			t.Errorf("#%d: verification with (s,r) should fail", i)
+		}
+	}
+}
+
+func TestVerifyGcrypt(t *testing.T) {
+	for _, c := range gcryptTestVec {
+		t.Run(c.curve.Params().Name, func(t *testing.T) {
+			data, _ := hex.DecodeString(c.Q)
+
+			x, y := elliptic.Unmarshal(c.curve, data)
+			if nil == x {
+				t.Fatal("invalid public key")
+			}
+			pub := &ecdsa.PublicKey{Curve: c.curve, X: x, Y: y}
+			if err := pub.Validate(); nil != err {
+				t.Fatal(err)
+			}
+
+			r, s := mustBigInt(c.r), mustBigInt(c.s)
+			if !gost3410.Verify(pub, []byte(gcryptMessage), r, s) {
+				t.Fatal("verification should pass")
+			}
+			if gost3410.Verify(pub, []byte(gcryptMessage+"."), r, s) {
+				t.Fatal("verification of another message should fail")
+			}
+		})
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	curves := []elliptic.Curve{
+		elliptic.GOST256A(),
+		elliptic.GOST256B(),
+		elliptic.GOST512A(),
+		elliptic.GOST512B(),
+		elliptic.GOST512C(),
+	}
+
+	msg := []byte("GOST R 34.10-2012")
+	for _, curve := range curves {
+		t.Run(curve.Params().Name, func(t *testing.T) {
+			priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+			if nil != err {
+				t.Fatal(err)
+			}
+
+			r, s, err := gost3410.Sign(rand.Reader, priv, msg)
+			if nil != err {
+				t.Fatal(err)
+			}
+			if !gost3410.Verify(&priv.PublicKey, msg, r, s) {
+				t.Fatal("verification should pass")
+			}
+
+			// the encoding should go through a round trip
+			sig := gost3410.Marshal(curve, r, s)
+			if len(sig) != 2*((curve.Params().N.BitLen()+7)/8) {
+				t.Fatalf("invalid signature length: %d", len(sig))
+			}
+			rDec, sDec, err := gost3410.Unmarshal(curve, sig)
+			if nil != err {
+				t.Fatal(err)
+			}
+			if (0 != rDec.Cmp(r)) || (0 != sDec.Cmp(s)) {
+				t.Fatalf("invalid decoded signature: got (%x,%x), want (%x,%x)", rDec, sDec, r, s)
+			}
+			if _, _, err := gost3410.Unmarshal(curve, sig[1:]); gost3410.ErrInvalidSignature != err {
+				t.Fatalf("invalid error: got %v, want %v", err, gost3410.ErrInvalidSignature)
+			}
+		})
+	}
+}
+
+func TestSignDigestHedged(t *testing.T) {
+	testCases := []struct {
+		name    string
+		curve   elliptic.Curve
+		newHash func() hash.Hash
+		other   func() hash.Hash
+	}{
+		{"GOST256A", elliptic.GOST256A(), streebog.New256, streebog.New512},
+		{"GOST512A", elliptic.GOST512A(), streebog.New512, streebog.New256},
+		{"GOST512C", elliptic.GOST512C(), streebog.New512, streebog.New256},
+	}
+
+	for _, c := range testCases {
+		t.Run(c.name, func(t *testing.T) {
+			priv, err := ecdsa.GenerateKey(c.curve, rand.Reader)
+			if nil != err {
+				t.Fatal(err)
+			}
+			h := gost3410.NewHash(c.curve)
+			h.Write([]byte("GOST R 34.10-2012"))
+			digest := h.Sum(nil)
+
+			// as many random bytes as the Streebog of the curve outputs
+			extra := make([]byte, c.newHash().Size())
+			if _, err := rand.Read(extra); nil != err {
+				t.Fatal(err)
+			}
+			if _, _, err := gost3410.SignDigest(bytes.NewReader(extra[:len(extra)-1]),
+				priv, digest); nil == err {
+				t.Fatal("the short randomness should be reported")
+			}
+
+			r, s, err := gost3410.SignDigest(bytes.NewReader(extra), priv, digest)
+			if nil != err {
+				t.Fatal(err)
+			}
+			if !gost3410.VerifyDigest(&priv.PublicKey, digest, r, s) {
+				t.Fatal("the signature should be valid")
+			}
+
+			// the nonce is drawn by the HMAC-DRBG over the Streebog of the curve
+			sign := func(newHash func() hash.Hash) (r, s *big.Int) {
+				g := ecdsa.NewNonceRFC6979(c.curve, priv.D, digest, newHash, extra)
+				defer g.Wipe()
+
+				r, s, err := gost3410.SignWithNonce(priv, digest, g.Next)
+				if nil != err {
+					t.Fatal(err)
+				}
+				return r, s
+			}
+
+			expectR, expectS := sign(c.newHash)
+			if (0 != r.Cmp(expectR)) || (0 != s.Cmp(expectS)) {
+				t.Fatalf("invalid signature: got (%x,%x), want (%x,%x)", r, s, expectR, expectS)
+			}
+			if otherR, _ := sign(c.other); 0 == r.Cmp(otherR) {
+				t.Fatal("the nonce should depend on the Streebog variant")
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package sm2
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/sm3"
+)
+
+// Mode specifies how the parts of the ciphertext are ordered, where C1 is the
+// uncompressed ephemeral point, C2 is the masked message and C3 is the hash
+// SM3(x2||M||y2)
+type Mode int
+
+const (
+	// C1C3C2 orders the ciphertext as C1||C3||C2, which is the one in
+	// GB/T 32918.4-2016
+	C1C3C2 Mode = iota
+	// C1C2C3 orders the ciphertext as C1||C2||C3, which is the one in the
+	// 2012 edition of the standard
+	C1C2C3
+)
+
+var (
+	// ErrEmptyMessage is returned when encrypting an empty message
+	ErrEmptyMessage = errors.New("sm2: the message is empty")
+	// ErrDecryption is returned if the ciphertext fails to decrypt, where
+	// the detail is left out on purpose
+	ErrDecryption = errors.New("sm2: decryption error")
+	// ErrInvalidMode is returned for an unknown ordering of the ciphertext
+	ErrInvalidMode = errors.New("sm2: invalid ciphertext mode")
+)
+
+// Encrypt encrypts the message to the public key by section 6.1 of
+// [GB/T 32918.4], drawing the ephemeral key from rand, and orders the
+// ciphertext as specified by mode
+func Encrypt(rand io.Reader, pub *ecdsa.PublicKey, msg []byte, mode Mode) ([]byte, error) {
+	c := pub.Curve
+	if c != elliptic.SM2P256() {
+		return nil, ErrCurveMismatch
+	}
+	if (C1C3C2 != mode) && (C1C2C3 != mode) {
+		return nil, ErrInvalidMode
+	}
+	if 0 == len(msg) {
+		return nil, ErrEmptyMessage
+	}
+	if err := pub.Validate(); nil != err {
+		return nil, err
+	}
+
+	for {
+		k, err := elliptic.RandScalar(c, rand, elliptic.ExtraRandomBits)
+		if nil != err {
+			return nil, err
+		}
+
+		// C1 = k*G, (x2,y2) = k*P
+		x1, y1 := c.ScalarBaseMult(k.Bytes())
+		x2, y2 := c.ScalarMult(pub.X, pub.Y, k.Bytes())
+		x2Bytes, y2Bytes := coordBytes(c, x2), coordBytes(c, y2)
+
+		// t = KDF(x2||y2, klen), which must not be all zeros
+		t := kdf(len(msg), x2Bytes, y2Bytes)
+		if isZero(t) {
+			continue
+		}
+
+		// C2 = M xor t
+		for i := range t {
+			t[i] ^= msg[i]
+		}
+		c3 := hashC3(x2Bytes, msg, y2Bytes)
+
+		out := elliptic.Marshal(c, x1, y1)
+		if C1C3C2 == mode {
+			out = append(out, c3...)
+			return append(out, t...), nil
+		}
+		out = append(out, t...)
+		return append(out, c3...), nil
+	}
+}
+
+// Decrypt decrypts the ciphertext ordered as specified by mode with the
+// private key by section 7.1 of [GB/T 32918.4]
+func Decrypt(priv *ecdsa.PrivateKey, ciphertext []byte, mode Mode) ([]byte, error) {
+	c := priv.Curve
+	if c != elliptic.SM2P256() {
+		return nil, ErrCurveMismatch
+	}
+	if (C1C3C2 != mode) && (C1C2C3 != mode) {
+		return nil, ErrInvalidMode
+	}
+
+	c1Len := 1 + 2*((c.Params().BitSize+7)>>3)
+	if len(ciphertext) <= c1Len+sm3.Size {
+		return nil, ErrDecryption
+	}
+
+	// C1 must be on the curve, whose cofactor is 1
+	x1, y1 := elliptic.Unmarshal(c, ciphertext[:c1Len])
+	if nil == x1 {
+		return nil, ErrDecryption
+	}
+
+	var c2, c3 []byte
+	if C1C3C2 == mode {
+		c3 = ciphertext[c1Len : c1Len+sm3.Size]
+		c2 = ciphertext[c1Len+sm3.Size:]
+	} else {
+		c2 = ciphertext[c1Len : len(ciphertext)-sm3.Size]
+		c3 = ciphertext[len(ciphertext)-sm3.Size:]
+	}
+
+	// (x2,y2) = d*C1
+	x2, y2 := c.ScalarMult(x1, y1, priv.D.Bytes())
+	x2Bytes, y2Bytes := coordBytes(c, x2), coordBytes(c, y2)
+
+	t := kdf(len(c2), x2Bytes, y2Bytes)
+	if isZero(t) {
+		return nil, ErrDecryption
+	}
+
+	// M = C2 xor t
+	for i := range t {
+		t[i] ^= c2[i]
+	}
+
+	if 1 != subtle.ConstantTimeCompare(hashC3(x2Bytes, t, y2Bytes), c3) {
+		return nil, ErrDecryption
+	}
+
+	return t, nil
+}
+
+// hashC3 computes C3 = SM3(x2||M||y2)
+func hashC3(x2, msg, y2 []byte) []byte {
+	h := sm3.New()
+	h.Write(x2)
+	h.Write(msg)
+	h.Write(y2)
+
+	return h.Sum(nil)
+}
+
+// isZero checks if all the bytes of b are zero
+func isZero(b []byte) bool {
+	var acc byte
+	for _, v := range b {
+		acc |= v
+	}
+
+	return 0 == acc
+}
@@ -0,0 +1,183 @@
+package sm2
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/sm3"
+)
+
+var (
+	// ErrNotInitialized is returned if Agree is called before Init, or
+	// Confirm before Agree
+	ErrNotInitialized = errors.New("sm2: the key exchange isn't initialized")
+	// ErrInvalidPoint is returned if the shared point is the point at
+	// infinity
+	ErrInvalidPoint = errors.New("sm2: invalid shared point")
+	// ErrConfirmation is returned if the confirmation from the peer mismatches
+	ErrConfirmation = errors.New("sm2: key confirmation failed")
+)
+
+// KeyExchange runs one side of the key exchange protocol of [GB/T 32918.3],
+// where the initiator is the user A and the responder is the user B. A
+// session goes as
+//  1. Both sides call Init, and send the returned ephemeral public key R to
+//     the other side.
+//  2. Both sides call Agree with the R of the other side to derive the key,
+//     and send the returned confirmation to the other side, which is SB for
+//     the responder and SA for the initiator.
+//  3. Both sides call Confirm with the confirmation of the other side.
+//
+// The confirmations are optional per the standard.
+type KeyExchange struct {
+	priv      *ecdsa.PrivateKey
+	peer      *ecdsa.PublicKey
+	za, zb    []byte // Z of the initiator and the responder
+	initiator bool
+
+	ephemeral    *ecdsa.PrivateKey
+	confirmation []byte // the expected confirmation from the peer
+}
+
+// NewKeyExchange prepares one side of the key exchange between priv with the
+// identity uid and the peer with the identity peerUID. A nil identity is
+// taken as DefaultUID.
+func NewKeyExchange(priv *ecdsa.PrivateKey, peer *ecdsa.PublicKey, uid, peerUID []byte,
+	initiator bool) (*KeyExchange, error) {
+	if (priv.Curve != elliptic.SM2P256()) || (peer.Curve != elliptic.SM2P256()) {
+		return nil, ErrCurveMismatch
+	}
+	if err := peer.Validate(); nil != err {
+		return nil, err
+	}
+
+	z, err := ZA(&priv.PublicKey, uid)
+	if nil != err {
+		return nil, err
+	}
+	zPeer, err := ZA(peer, peerUID)
+	if nil != err {
+		return nil, err
+	}
+
+	kx := &KeyExchange{priv: priv, peer: peer, initiator: initiator}
+	if initiator {
+		kx.za, kx.zb = z, zPeer
+	} else {
+		kx.za, kx.zb = zPeer, z
+	}
+
+	return kx, nil
+}
+
+// Init generates the ephemeral key pair from rand, and returns the public key
+// R to send to the peer
+func (kx *KeyExchange) Init(rand io.Reader) (*ecdsa.PublicKey, error) {
+	ephemeral, err := ecdsa.GenerateKey(elliptic.SM2P256(), rand)
+	if nil != err {
+		return nil, err
+	}
+	kx.ephemeral = ephemeral
+
+	return &ephemeral.PublicKey, nil
+}
+
+// Agree derives the shared key of klen bytes with the ephemeral public key R
+// of the peer, and returns the confirmation to send to the peer
+func (kx *KeyExchange) Agree(peerR *ecdsa.PublicKey, klen int) (key, confirmation []byte, err error) {
+	if nil == kx.ephemeral {
+		return nil, nil, ErrNotInitialized
+	}
+	if peerR.Curve != elliptic.SM2P256() {
+		return nil, nil, ErrCurveMismatch
+	}
+	if err := peerR.Validate(); nil != err {
+		return nil, nil, err
+	}
+
+	c := kx.priv.Curve
+	N := c.Params().N
+	R := &kx.ephemeral.PublicKey
+
+	// t = (d+xBar*r) mod N
+	t := xBar(c, R.X)
+	t.Mul(t, kx.ephemeral.D)
+	t.Add(t, kx.priv.D)
+	t.Mod(t, N)
+
+	// V = h*t*(P+xBar'*R'), where h = 1
+	x, y := c.ScalarMult(peerR.X, peerR.Y, xBar(c, peerR.X).Bytes())
+	x, y = c.Add(kx.peer.X, kx.peer.Y, x, y)
+	vx, vy := c.ScalarMult(x, y, t.Bytes())
+	if (0 == vx.Sign()) && (0 == vy.Sign()) {
+		return nil, nil, ErrInvalidPoint
+	}
+	xV, yV := coordBytes(c, vx), coordBytes(c, vy)
+
+	// K = KDF(xV||yV||ZA||ZB, klen)
+	key = kdf(klen, xV, yV, kx.za, kx.zb)
+
+	// SM3(xV||ZA||ZB||x1||y1||x2||y2), where (x1,y1) is the R of the
+	// initiator and (x2,y2) is the one of the responder
+	RA, RB := R, peerR
+	if !kx.initiator {
+		RA, RB = peerR, R
+	}
+	h := sm3.New()
+	for _, v := range [][]byte{xV, kx.za, kx.zb} {
+		h.Write(v)
+	}
+	for _, v := range []*big.Int{RA.X, RA.Y, RB.X, RB.Y} {
+		h.Write(coordBytes(c, v))
+	}
+	transcript := h.Sum(nil)
+
+	// SB = S1 = SM3(0x02||yV||...), SA = S2 = SM3(0x03||yV||...)
+	sb, sa := confirm(0x02, yV, transcript), confirm(0x03, yV, transcript)
+	if kx.initiator {
+		kx.confirmation = sb
+		return key, sa, nil
+	}
+
+	kx.confirmation = sa
+	return key, sb, nil
+}
+
+// Confirm checks the confirmation from the peer, which is SB for the
+// initiator and SA for the responder
+func (kx *KeyExchange) Confirm(peerConfirmation []byte) error {
+	if nil == kx.confirmation {
+		return ErrNotInitialized
+	}
+
+	if 1 != subtle.ConstantTimeCompare(kx.confirmation, peerConfirmation) {
+		return ErrConfirmation
+	}
+
+	return nil
+}
+
+// xBar computes 2^w+(x&(2^w-1)), where w = ceil(ceil(log2(N))/2)-1
+func xBar(c elliptic.Curve, x *big.Int) *big.Int {
+	w := uint((c.Params().N.BitLen()+1)/2 - 1)
+
+	twoW := new(big.Int).Lsh(big.NewInt(1), w)
+	out := new(big.Int).Sub(twoW, big.NewInt(1))
+	out.And(out, x)
+
+	return out.Add(out, twoW)
+}
+
+// confirm computes SM3(tag||yV||transcript)
+func confirm(tag byte, yV, transcript []byte) []byte {
+	h := sm3.New()
+	h.Write([]byte{tag})
+	h.Write(yV)
+	h.Write(transcript)
+
+	return h.Sum(nil)
+}
@@ -0,0 +1,126 @@
+package sm2_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/sammy00/crypto/sm2"
+)
+
+// ephemeralReader feeds elliptic.ExtraRandomBits with the bits making up the
+// given private scalar d, i.e., 64 zero bits followed by d-1
+func ephemeralReader(d string) *bytes.Reader {
+	dMinus1 := mustBigInt(d)
+	dMinus1.Sub(dMinus1, mustBigInt("1"))
+
+	buf := make([]byte, 40)
+	copy(buf[40-len(dMinus1.Bytes()):], dMinus1.Bytes())
+
+	return bytes.NewReader(buf)
+}
+
+func TestKeyExchange(t *testing.T) {
+	privA, privB := mustPrivateKey(t, privA), mustPrivateKey(t, privB)
+	uidA, uidB := []byte("ALICE123@YAHOO.COM"), []byte("BILL456@YAHOO.COM")
+
+	alice, err := sm2.NewKeyExchange(privA, &privB.PublicKey, uidA, uidB, true)
+	if nil != err {
+		t.Fatal(err)
+	}
+	bob, err := sm2.NewKeyExchange(privB, &privA.PublicKey, uidB, uidA, false)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	RA, err := alice.Init(ephemeralReader(ephemeralA))
+	if nil != err {
+		t.Fatal(err)
+	}
+	RB, err := bob.Init(ephemeralReader(ephemeralB))
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	keyB, SB, err := bob.Agree(RA, 16)
+	if nil != err {
+		t.Fatal(err)
+	}
+	keyA, SA, err := alice.Agree(RB, 16)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	if got := hex.EncodeToString(keyA); got != keyExchangeKey {
+		t.Fatalf("invalid key of A: got %s, want %s", got, keyExchangeKey)
+	}
+	if got := hex.EncodeToString(keyB); got != keyExchangeKey {
+		t.Fatalf("invalid key of B: got %s, want %s", got, keyExchangeKey)
+	}
+	if bytes.Equal(SA, SB) {
+		t.Fatal("SA and SB should differ by their prefixes")
+	}
+
+	if err := alice.Confirm(SB); nil != err {
+		t.Fatal(err)
+	}
+	if err := bob.Confirm(SA); nil != err {
+		t.Fatal(err)
+	}
+	if err := alice.Confirm(SA); sm2.ErrConfirmation != err {
+		t.Fatalf("invalid error: got %v, want %v", err, sm2.ErrConfirmation)
+	}
+}
+
+func TestKeyExchangeRandom(t *testing.T) {
+	privA, err := sm2.GenerateKey(rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	privB, err := sm2.GenerateKey(rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	alice, err := sm2.NewKeyExchange(privA, &privB.PublicKey, nil, nil, true)
+	if nil != err {
+		t.Fatal(err)
+	}
+	bob, err := sm2.NewKeyExchange(privB, &privA.PublicKey, nil, nil, false)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	if _, _, err := alice.Agree(&privB.PublicKey, 32); sm2.ErrNotInitialized != err {
+		t.Fatalf("invalid error: got %v, want %v", err, sm2.ErrNotInitialized)
+	}
+
+	RA, err := alice.Init(rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	RB, err := bob.Init(rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	keyA, SA, err := alice.Agree(RB, 48)
+	if nil != err {
+		t.Fatal(err)
+	}
+	keyB, SB, err := bob.Agree(RA, 48)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(keyA, keyB) {
+		t.Fatalf("keys mismatch: %x vs %x", keyA, keyB)
+	}
+	if err := alice.Confirm(SB); nil != err {
+		t.Fatal(err)
+	}
+	if err := bob.Confirm(SA); nil != err {
+		t.Fatal(err)
+	}
+}
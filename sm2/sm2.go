@@ -0,0 +1,254 @@
+// Package sm2 implements the SM2 public key algorithms over the curve
+// sm2p256v1, i.e., the digital signature, the public key encryption and the
+// key exchange protocol specified by GB/T 32918. The keys are the ones of the
+// ecdsa package over elliptic.SM2P256(), and SM3 is the only hash in use.
+package sm2
+
+// References:
+//   [GB/T 32918.2]: Information security technology -- Public key
+//     cryptographic algorithm SM2 based on elliptic curves -- Part 2:
+//     Digital signature algorithm
+//   [GB/T 32918.3]: ... -- Part 3: Key exchange protocol
+//   [GB/T 32918.4]: ... -- Part 4: Public key encryption algorithm
+//   [draft-shen-sm2-ecdsa]: SM2 Digital Signature Algorithm,
+//     https://datatracker.ietf.org/doc/html/draft-shen-sm2-ecdsa-02
+//   [RFC6979]: Deterministic Usage of the Digital Signature Algorithm (DSA)
+//     and Elliptic Curve Digital Signature Algorithm (ECDSA),
+//     https://tools.ietf.org/html/rfc6979
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/misc"
+	"github.com/sammy00/crypto/sm3"
+)
+
+// DefaultUID is the user identity taken when none is given, as is
+// recommended by GM/T 0009
+var DefaultUID = []byte("1234567812345678")
+
+var (
+	// ErrCurveMismatch is returned if the key is not on sm2p256v1
+	ErrCurveMismatch = errors.New("sm2: the key is not on sm2p256v1")
+	// ErrUIDTooLong is returned if the bit length of the user identity
+	// overflows the 2 bytes of ENTL
+	ErrUIDTooLong = errors.New("sm2: the user identity is too long")
+	// ErrInvalidPrivateKey is returned if the private key is out of
+	// [1,N-2], for which 1+d isn't invertible
+	ErrInvalidPrivateKey = errors.New("sm2: invalid private key")
+)
+
+// GenerateKey generates a key pair over sm2p256v1, whose private key is in
+// [1,N-2] as is required by the signature
+func GenerateKey(rand io.Reader) (*ecdsa.PrivateKey, error) {
+	nMinus1 := new(big.Int).Sub(elliptic.SM2P256().Params().N, big.NewInt(1))
+	for {
+		priv, err := ecdsa.GenerateKey(elliptic.SM2P256(), rand)
+		if nil != err {
+			return nil, err
+		}
+
+		if 0 != priv.D.Cmp(nMinus1) {
+			return priv, nil
+		}
+	}
+}
+
+// ZA computes the hash of the user identity and the public key, i.e.,
+// SM3(ENTL||ID||a||b||Gx||Gy||X||Y) by section 5.5 of [GB/T 32918.2], where
+// ENTL is the bit length of the identity in 2 bytes. A nil uid is taken as
+// DefaultUID.
+func ZA(pub *ecdsa.PublicKey, uid []byte) ([]byte, error) {
+	if pub.Curve != elliptic.SM2P256() {
+		return nil, ErrCurveMismatch
+	}
+	if nil == uid {
+		uid = DefaultUID
+	}
+	if len(uid) >= 1<<13 {
+		return nil, ErrUIDTooLong
+	}
+
+	params := pub.Curve.Params()
+	// a = -3 mod P
+	a := new(big.Int).Sub(params.P, big.NewInt(3))
+
+	h := sm3.New()
+	entl := len(uid) << 3
+	h.Write([]byte{byte(entl >> 8), byte(entl)})
+	h.Write(uid)
+	for _, v := range []*big.Int{a, params.B, params.Gx, params.Gy, pub.X, pub.Y} {
+		h.Write(coordBytes(pub.Curve, v))
+	}
+
+	return h.Sum(nil), nil
+}
+
+// Digest computes e = SM3(ZA||msg), which is the hash to sign for the message
+func Digest(pub *ecdsa.PublicKey, msg, uid []byte) ([]byte, error) {
+	za, err := ZA(pub, uid)
+	if nil != err {
+		return nil, err
+	}
+
+	h := sm3.New()
+	h.Write(za)
+	h.Write(msg)
+
+	return h.Sum(nil), nil
+}
+
+// Sign signs the message with the user identity uid by section 6.1 of
+// [GB/T 32918.2], with the hedged nonce of SignDigest. A nil uid is taken as
+// DefaultUID.
+func Sign(rand io.Reader, priv *ecdsa.PrivateKey, msg, uid []byte) (r, s *big.Int, err error) {
+	e, err := Digest(&priv.PublicKey, msg, uid)
+	if nil != err {
+		return nil, nil, err
+	}
+
+	return SignDigest(rand, priv, e)
+}
+
+// SignDigest signs the digest e = SM3(ZA||msg) computed by Digest. The nonce
+// k is derived with the HMAC-DRBG of [RFC6979] over SM3 from the private key,
+// the digest and 32 bytes read from rand, so that it stays secret even if
+// rand is broken.
+func SignDigest(rand io.Reader, priv *ecdsa.PrivateKey, digest []byte) (r, s *big.Int, err error) {
+	c := priv.Curve
+	if c != elliptic.SM2P256() {
+		return nil, nil, ErrCurveMismatch
+	}
+
+	// 1+d must be invertible
+	if (nil == priv.D) || (priv.D.Sign() <= 0) ||
+		(priv.D.Cmp(new(big.Int).Sub(c.Params().N, big.NewInt(1))) >= 0) {
+		return nil, nil, ErrInvalidPrivateKey
+	}
+
+	extra := make([]byte, sm3.Size)
+	if _, err := io.ReadFull(rand, extra); nil != err {
+		return nil, nil, err
+	}
+
+	g := ecdsa.NewNonceRFC6979(c, priv.D, digest, sm3.New, extra)
+	defer g.Wipe()
+
+	return signWithNonce(priv, digest, g.Next)
+}
+
+// signWithNonce signs the digest with the nonces drawn from next, where the
+// private key is already checked
+func signWithNonce(priv *ecdsa.PrivateKey, digest []byte,
+	next func() (*big.Int, error)) (r, s *big.Int, err error) {
+	c := priv.Curve
+	N := c.Params().N
+
+	dPlus1Inv := new(big.Int).Add(priv.D, big.NewInt(1))
+	dPlus1Inv.ModInverse(dPlus1Inv, N)
+
+	e := new(big.Int).SetBytes(digest)
+	for {
+		k, err := next()
+		if nil != err {
+			return nil, nil, err
+		}
+
+		// r = (e+x1) mod N, where (x1,y1) = k*G
+		x1, _ := c.ScalarBaseMult(k.Bytes())
+		r = new(big.Int).Add(e, x1)
+		r.Mod(r, N)
+		if (0 == r.Sign()) || (0 == new(big.Int).Add(r, k).Cmp(N)) {
+			continue
+		}
+
+		// s = (1+d)^{-1}*(k-r*d) mod N
+		s = new(big.Int).Mul(r, priv.D)
+		s.Sub(k, s)
+		s.Mul(s, dPlus1Inv)
+		s.Mod(s, N)
+		if 0 != s.Sign() {
+			return r, s, nil
+		}
+	}
+}
+
+// Verify verifies the signature (r,s) of the message with the user identity
+// uid by section 7.1 of [GB/T 32918.2]. A nil uid is taken as DefaultUID.
+func Verify(pub *ecdsa.PublicKey, msg, uid []byte, r, s *big.Int) bool {
+	e, err := Digest(pub, msg, uid)
+	if nil != err {
+		return false
+	}
+
+	return VerifyDigest(pub, e, r, s)
+}
+
+// VerifyDigest verifies the signature (r,s) of the digest e = SM3(ZA||msg)
+// computed by Digest
+func VerifyDigest(pub *ecdsa.PublicKey, digest []byte, r, s *big.Int) bool {
+	c := pub.Curve
+	if c != elliptic.SM2P256() {
+		return false
+	}
+	N := c.Params().N
+
+	// ensure r,s in [1,n-1]
+	if (r.Sign() <= 0) || (s.Sign() <= 0) {
+		return false
+	}
+	if (r.Cmp(N) >= 0) || (s.Cmp(N) >= 0) {
+		return false
+	}
+
+	// t = (r+s) mod N
+	t := new(big.Int).Add(r, s)
+	t.Mod(t, N)
+	if 0 == t.Sign() {
+		return false
+	}
+
+	// (x1,y1) = s*G+t*P
+	x1, y1 := c.ScalarBaseMult(s.Bytes())
+	x2, y2 := c.ScalarMult(pub.X, pub.Y, t.Bytes())
+	x1, _ = c.Add(x1, y1, x2, y2)
+
+	// R = (e+x1) mod N
+	R := new(big.Int).SetBytes(digest)
+	R.Add(R, x1)
+	R.Mod(R, N)
+
+	return 0 == R.Cmp(r)
+}
+
+// coordBytes encodes the coordinate x into the fixed-width big endian bytes
+func coordBytes(c elliptic.Curve, x *big.Int) []byte {
+	out := make([]byte, (c.Params().BitSize+7)>>3)
+	misc.ReverseCopy(out, x.Bytes())
+
+	return out
+}
+
+// kdf derives klen bytes from z by section 5.4.3 of [GB/T 32918.4], i.e.,
+// SM3(z||ct) for the 32-bit counter ct starting from 1
+func kdf(klen int, z ...[]byte) []byte {
+	out := make([]byte, 0, klen+sm3.Size)
+
+	var ct [4]byte
+	for i := uint32(1); len(out) < klen; i++ {
+		ct[0], ct[1], ct[2], ct[3] = byte(i>>24), byte(i>>16), byte(i>>8), byte(i)
+
+		h := sm3.New()
+		for _, v := range z {
+			h.Write(v)
+		}
+		h.Write(ct[:])
+		out = h.Sum(out)
+	}
+
+	return out[:klen]
+}
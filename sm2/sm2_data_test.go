@@ -0,0 +1,48 @@
+package sm2_test
+
+// the fixtures below are produced by github.com/tjfoc/gmsm v1.4.1
+
+const (
+	privA = "81eb26e941bb5af16df116495f90695272ae2cd63d6c4ae1678418be48230029"
+	privB = "785129917d45a9ea5437a59356b82338eaadda6ceb199088f14ae10defa229b5"
+	// the ephemeral private keys of the key exchange
+	ephemeralA = "d4de15474db74d06491c440d305e012400990f3e390c7e87153c12db2ea60bb3"
+	ephemeralB = "7e07124814b309489125eaed101113164ebf0f3458c5bd88335c1f9d596243d6"
+)
+
+var signatureTestVec = []struct {
+	uid  string
+	za   string
+	msg  string
+	r, s string
+}{
+	{
+		"1234567812345678",
+		"3b85a57179e11e7e513aa622991f2ca74d1807a0bd4d4b38f90987a17ac245b1",
+		"message digest",
+		"ff7f39dbd7952395432ae5b31f7697fe329d7b3cc5e0b150966c7493530a3087",
+		"619d0e9623b1492d6291aca7f2565f3479035a8816d8325a3c1180fc334f8fd7",
+	},
+	{
+		"ALICE123@YAHOO.COM",
+		"dd302b546d31a872ad6bbe778e3683929ac79580635b48fb9f55fe2858fdd286",
+		"message digest",
+		"fe68112e46399759ba0af9efbd0924180be7fcd6e0c6bf581ee0e83a48675ce5",
+		"521aacb512a7a419f8abb41823c1c8fb340f7ac0031519c3ee48ea34ff356bfa",
+	},
+}
+
+// ciphertexts of "encryption standard" to the public key of privB
+var encryptionTestVec = []struct {
+	mode       int
+	ciphertext string
+}{
+	{0, "04941f79c0216f415c7fb54c6a5f78ba141e3c50ffcc82f623c13a900e333c65c829e49624178d293c29c33c435e055aa8b93315edc74926a8b7acc113dc903c4824e1e97f2383686d1e5f16e2a8b8f03edd01b60a8171eccf591f45ef039319b244e63079d624c562cc4e18f77ef8e6bb1c7e4c"},
+	{1, "041f56fec5f43998109f8de007cd724c26f80944bb37a33560199cb2cd0477bd5376b3258a3113fca2d69bb6c5c4b8cd1008676ec2387eb8b83ca5e8930d131027741466fbe211691e4a0b73fd7ebc739604e832ad9987cd4ac024e2bb1937dac193ac7bbd121c007bbbc9ef121c38d29a83c435"},
+}
+
+// the key agreed by privA with the identity "ALICE123@YAHOO.COM" and privB
+// with the identity "BILL456@YAHOO.COM". gmsm hashes x2||y2 ahead of x1||y1
+// against section 6.1 of GB/T 32918.3, so its confirmations SB and SA are no
+// fixtures, which are only cross-checked between both parties.
+const keyExchangeKey = "3c1362830b075a6f891dbf651997bf50"
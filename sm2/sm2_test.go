@@ -0,0 +1,220 @@
+package sm2_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/sammy00/crypto/ecdsa"
+	"github.com/sammy00/crypto/elliptic"
+	"github.com/sammy00/crypto/sm2"
+)
+
+// mustPrivateKey builds the private key over sm2p256v1 from the hex scalar
+func mustPrivateKey(t *testing.T, d string) *ecdsa.PrivateKey {
+	D, _ := new(big.Int).SetString(d, 16)
+
+	priv, err := ecdsa.NewPrivateKey(elliptic.SM2P256(), D)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	return priv
+}
+
+func mustBigInt(s string) *big.Int {
+	x, _ := new(big.Int).SetString(s, 16)
+	return x
+}
+
+func TestZA(t *testing.T) {
+	priv := mustPrivateKey(t, privA)
+
+	for i, c := range signatureTestVec {
+		za, err := sm2.ZA(&priv.PublicKey, []byte(c.uid))
+		if nil != err {
+			t.Fatal(err)
+		}
+
+		if got := hex.EncodeToString(za); got != c.za {
+			t.Errorf("#%d: invalid ZA: got %s, want %s", i, got, c.za)
+		}
+	}
+
+	// nil falls back to the default identity
+	za, err := sm2.ZA(&priv.PublicKey, nil)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(za); got != signatureTestVec[0].za {
+		t.Fatalf("invalid default ZA: got %s, want %s", got, signatureTestVec[0].za)
+	}
+
+	if _, err := sm2.ZA(&priv.PublicKey, make([]byte, 1<<13)); sm2.ErrUIDTooLong != err {
+		t.Fatalf("invalid error: got %v, want %v", err, sm2.ErrUIDTooLong)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	priv := mustPrivateKey(t, privA)
+
+	for i, c := range signatureTestVec {
+		uid, msg := []byte(c.uid), []byte(c.msg)
+		r, s := mustBigInt(c.r), mustBigInt(c.s)
+
+		if !sm2.Verify(&priv.PublicKey, msg, uid, r, s) {
+			t.Errorf("#%d: verification should pass", i)
+		}
+		if sm2.Verify(&priv.PublicKey, msg, []byte("BILL456@YAHOO.COM"), r, s) {
+			t.Errorf("#%d: verification with another identity should fail", i)
+		}
+		if sm2.Verify(&priv.PublicKey, []byte("message digesT"), uid, r, s) {
+			t.Errorf("#%d: verification of another message should fail", i)
+		}
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	priv, err := sm2.GenerateKey(rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	msg := []byte("message digest")
+	r, s, err := sm2.Sign(rand.Reader, priv, msg, nil)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	if !sm2.Verify(&priv.PublicKey, msg, nil, r, s) {
+		t.Fatal("verification should pass")
+	}
+	if !sm2.Verify(&priv.PublicKey, msg, sm2.DefaultUID, r, s) {
+		t.Fatal("verification with the default identity should pass")
+	}
+
+	// the plain ecdsa verification shouldn't accept the signature
+	e, err := sm2.Digest(&priv.PublicKey, msg, nil)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if ecdsa.Verify(&priv.PublicKey, e, r, s) {
+		t.Fatal("ecdsa verification should fail")
+	}
+
+	// keys on other curves are rejected
+	other, err := ecdsa.GenerateKey(elliptic.P256k1(), rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if _, _, err := sm2.Sign(rand.Reader, other, msg, nil); sm2.ErrCurveMismatch != err {
+		t.Fatalf("invalid error: got %v, want %v", err, sm2.ErrCurveMismatch)
+	}
+}
+
+func TestSignDigestHedged(t *testing.T) {
+	priv := mustPrivateKey(t, privA)
+	e, err := sm2.Digest(&priv.PublicKey, []byte("message digest"), nil)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	// the nonce depends on the randomness, the key and the digest only
+	zeros := make([]byte, 32)
+	r1, s1, err := sm2.SignDigest(bytes.NewReader(zeros), priv, e)
+	if nil != err {
+		t.Fatal(err)
+	}
+	r2, s2, err := sm2.SignDigest(bytes.NewReader(zeros), priv, e)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if (0 != r1.Cmp(r2)) || (0 != s1.Cmp(s2)) {
+		t.Fatal("the same randomness should give the same signature")
+	}
+	if !sm2.VerifyDigest(&priv.PublicKey, e, r1, s1) {
+		t.Fatal("verification should pass even with broken randomness")
+	}
+
+	r3, _, err := sm2.SignDigest(rand.Reader, priv, e)
+	if nil != err {
+		t.Fatal(err)
+	}
+	if 0 == r1.Cmp(r3) {
+		t.Fatal("fresh randomness should give another nonce")
+	}
+
+	if _, _, err := sm2.SignDigest(bytes.NewReader(zeros[:16]), priv, e); nil == err {
+		t.Fatal("the short randomness should be reported")
+	}
+}
+
+func TestSignInvalidPrivateKey(t *testing.T) {
+	nMinus1 := new(big.Int).Sub(elliptic.SM2P256().Params().N, big.NewInt(1))
+	priv := mustPrivateKey(t, hex.EncodeToString(nMinus1.Bytes()))
+
+	if _, _, err := sm2.Sign(rand.Reader, priv, []byte("abc"), nil); sm2.ErrInvalidPrivateKey != err {
+		t.Fatalf("invalid error: got %v, want %v", err, sm2.ErrInvalidPrivateKey)
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	priv := mustPrivateKey(t, privB)
+	want := []byte("encryption standard")
+
+	for i, c := range encryptionTestVec {
+		ciphertext, _ := hex.DecodeString(c.ciphertext)
+
+		got, err := sm2.Decrypt(priv, ciphertext, sm2.Mode(c.mode))
+		if nil != err {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("#%d: invalid message: got %s, want %s", i, got, want)
+		}
+
+		// the other ordering shouldn't decrypt
+		if _, err := sm2.Decrypt(priv, ciphertext, sm2.Mode(1-c.mode)); sm2.ErrDecryption != err {
+			t.Errorf("#%d: invalid error: got %v, want %v", i, err, sm2.ErrDecryption)
+		}
+	}
+}
+
+func TestEncryptAndDecrypt(t *testing.T) {
+	priv, err := sm2.GenerateKey(rand.Reader)
+	if nil != err {
+		t.Fatal(err)
+	}
+
+	// spans several blocks of the KDF
+	msg := bytes.Repeat([]byte("encryption standard"), 5)
+	for _, mode := range []sm2.Mode{sm2.C1C3C2, sm2.C1C2C3} {
+		ciphertext, err := sm2.Encrypt(rand.Reader, &priv.PublicKey, msg, mode)
+		if nil != err {
+			t.Fatal(err)
+		}
+		if len(ciphertext) != 65+32+len(msg) {
+			t.Fatalf("invalid ciphertext length: got %d, want %d", len(ciphertext), 65+32+len(msg))
+		}
+
+		got, err := sm2.Decrypt(priv, ciphertext, mode)
+		if nil != err {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, msg) {
+			t.Fatalf("invalid message: got %s, want %s", got, msg)
+		}
+
+		// any tampering should be detected
+		ciphertext[len(ciphertext)-1] ^= 0x01
+		if _, err := sm2.Decrypt(priv, ciphertext, mode); sm2.ErrDecryption != err {
+			t.Fatalf("invalid error: got %v, want %v", err, sm2.ErrDecryption)
+		}
+	}
+
+	if _, err := sm2.Encrypt(rand.Reader, &priv.PublicKey, nil, sm2.C1C3C2); sm2.ErrEmptyMessage != err {
+		t.Fatalf("invalid error: got %v, want %v", err, sm2.ErrEmptyMessage)
+	}
+}
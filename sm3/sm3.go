@@ -0,0 +1,175 @@
+// Package sm3 implements the SM3 hash algorithm specified in GB/T 32905-2016,
+// which is the hash used by the SM2 public key algorithms.
+package sm3
+
+// References:
+//   [GB/T 32905]: Information security technology -- SM3 cryptographic hash
+//     algorithm
+//   [draft-sca-cfrg-sm3]: The SM3 Cryptographic Hash Function,
+//     https://datatracker.ietf.org/doc/html/draft-sca-cfrg-sm3-02
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// Size is the size of an SM3 checksum in bytes
+const Size = 32
+
+// BlockSize is the block size of SM3 in bytes
+const BlockSize = 64
+
+// iv is the initial value of the compression function
+var iv = [8]uint32{
+	0x7380166f, 0x4914b2b9, 0x172442d7, 0xda8a0600,
+	0xa96f30bc, 0x163138aa, 0xe38dee4d, 0xb0fb0e4e,
+}
+
+// digest represents the partial evaluation of an SM3 checksum
+type digest struct {
+	h   [8]uint32
+	x   [BlockSize]byte
+	nx  int
+	len uint64
+}
+
+// New returns a new hash.Hash computing the SM3 checksum
+func New() hash.Hash {
+	d := new(digest)
+	d.Reset()
+
+	return d
+}
+
+// Sum returns the SM3 checksum of the data
+func Sum(data []byte) [Size]byte {
+	var d digest
+	d.Reset()
+	d.Write(data)
+
+	var out [Size]byte
+	d.Sum(out[:0])
+
+	return out
+}
+
+func (d *digest) Reset() {
+	d.h = iv
+	d.nx = 0
+	d.len = 0
+}
+
+func (d *digest) Size() int { return Size }
+
+func (d *digest) BlockSize() int { return BlockSize }
+
+func (d *digest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.len += uint64(n)
+
+	if d.nx > 0 {
+		m := copy(d.x[d.nx:], p)
+		d.nx += m
+		if BlockSize == d.nx {
+			block(&d.h, d.x[:])
+			d.nx = 0
+		}
+		p = p[m:]
+	}
+
+	for len(p) >= BlockSize {
+		block(&d.h, p[:BlockSize])
+		p = p[BlockSize:]
+	}
+
+	if len(p) > 0 {
+		d.nx = copy(d.x[:], p)
+	}
+
+	return n, nil
+}
+
+// Sum appends the checksum to in without changing the underlying state
+func (d *digest) Sum(in []byte) []byte {
+	dd := *d
+
+	// padding: 0x80, zeros up to 56 mod 64, then the bit length in 64 bits
+	var tmp [BlockSize + 8]byte
+	tmp[0] = 0x80
+	padLen := 56 - int(dd.len%BlockSize)
+	if padLen <= 0 {
+		padLen += BlockSize
+	}
+	binary.BigEndian.PutUint64(tmp[padLen:], dd.len<<3)
+	dd.Write(tmp[:padLen+8])
+
+	var out [Size]byte
+	for i, v := range dd.h {
+		binary.BigEndian.PutUint32(out[i*4:], v)
+	}
+
+	return append(in, out[:]...)
+}
+
+// p0 is the permutation function P0 used in the compression
+func p0(x uint32) uint32 {
+	return x ^ bits.RotateLeft32(x, 9) ^ bits.RotateLeft32(x, 17)
+}
+
+// p1 is the permutation function P1 used in the message expansion
+func p1(x uint32) uint32 {
+	return x ^ bits.RotateLeft32(x, 15) ^ bits.RotateLeft32(x, 23)
+}
+
+// block runs the compression function CF over every 64-byte block in p
+func block(h *[8]uint32, p []byte) {
+	var w [68]uint32
+	var w1 [64]uint32
+
+	for ; len(p) >= BlockSize; p = p[BlockSize:] {
+		// message expansion
+		for i := 0; i < 16; i++ {
+			w[i] = binary.BigEndian.Uint32(p[i*4:])
+		}
+		for i := 16; i < 68; i++ {
+			w[i] = p1(w[i-16]^w[i-9]^bits.RotateLeft32(w[i-3], 15)) ^
+				bits.RotateLeft32(w[i-13], 7) ^ w[i-6]
+		}
+		for i := 0; i < 64; i++ {
+			w1[i] = w[i] ^ w[i+4]
+		}
+
+		a, b, c, dd, e, f, g, hh := h[0], h[1], h[2], h[3], h[4], h[5], h[6], h[7]
+		for j := 0; j < 64; j++ {
+			var t, ff, gg uint32
+			if j < 16 {
+				t = 0x79cc4519
+				ff = a ^ b ^ c
+				gg = e ^ f ^ g
+			} else {
+				t = 0x7a879d8a
+				ff = (a & b) | (a & c) | (b & c)
+				gg = (e & f) | (^e & g)
+			}
+
+			a12 := bits.RotateLeft32(a, 12)
+			ss1 := bits.RotateLeft32(a12+e+bits.RotateLeft32(t, j%32), 7)
+			ss2 := ss1 ^ a12
+			tt1 := ff + dd + ss2 + w1[j]
+			tt2 := gg + hh + ss1 + w[j]
+
+			dd, c, b, a = c, bits.RotateLeft32(b, 9), a, tt1
+			hh, g, f, e = g, bits.RotateLeft32(f, 19), e, p0(tt2)
+		}
+
+		h[0] ^= a
+		h[1] ^= b
+		h[2] ^= c
+		h[3] ^= dd
+		h[4] ^= e
+		h[5] ^= f
+		h[6] ^= g
+		h[7] ^= hh
+	}
+}
@@ -0,0 +1,39 @@
+package sm3_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/sammy00/crypto/sm3"
+)
+
+func TestSum(t *testing.T) {
+	testCases := []struct {
+		msg    string
+		digest string
+	}{
+		// the examples in appendix A of GB/T 32905
+		{"abc", "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0"},
+		{strings.Repeat("abcd", 16), "debe9ff92275b8a138604889c18e5a4d6fdb70e5387e5765293dcba39c0c5732"},
+		{"", "1ab21d8355cfa17f8e61194831e81a8f22bec8c728fefb747ed035eb5082aa2b"},
+	}
+
+	for i, c := range testCases {
+		want, _ := hex.DecodeString(c.digest)
+
+		if got := sm3.Sum([]byte(c.msg)); !bytes.Equal(got[:], want) {
+			t.Errorf("#%d: invalid digest: got %x, want %x", i, got, want)
+		}
+
+		// writing byte by byte should make no difference
+		h := sm3.New()
+		for j := 0; j < len(c.msg); j++ {
+			h.Write([]byte{c.msg[j]})
+		}
+		if got := h.Sum(nil); !bytes.Equal(got, want) {
+			t.Errorf("#%d: invalid streamed digest: got %x, want %x", i, got, want)
+		}
+	}
+}
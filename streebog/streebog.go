@@ -0,0 +1,233 @@
+// Package streebog implements the Streebog hash functions of 256 and 512 bits
+// specified in GOST R 34.11-2012.
+//
+// As is the convention of [RFC6986], a 512-bit vector is stored in little
+// endian, i.e., the message is processed from the beginning in blocks of 64
+// bytes, and the digest is the little-endian encoding of the hash vector.
+package streebog
+
+// References:
+//   [RFC6986]: GOST R 34.11-2012: Hash Function,
+//     https://tools.ietf.org/html/rfc6986
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// Size256 is the size of a Streebog-256 checksum in bytes
+const Size256 = 32
+
+// Size512 is the size of a Streebog-512 checksum in bytes
+const Size512 = 64
+
+// BlockSize is the block size of Streebog in bytes
+const BlockSize = 64
+
+// lps is the precomputed composition of the transformations L, P and S, where
+// lps[j][v] is L applied to the word whose j-th byte is pi[v]
+var lps [8][256]uint64
+
+func init() {
+	for j := 0; j < 8; j++ {
+		for v := 0; v < 256; v++ {
+			x := uint64(pi[v]) << uint(8*j)
+
+			var out uint64
+			for i := 0; i < 64; i++ {
+				if 1 == (x>>uint(63-i))&1 {
+					out ^= a[i]
+				}
+			}
+			lps[j][v] = out
+		}
+	}
+}
+
+// digest represents the partial evaluation of a Streebog checksum
+type digest struct {
+	h     [8]uint64 // the chaining value
+	n     [8]uint64 // the number of hashed bits
+	sigma [8]uint64 // the sum of the hashed blocks
+	x     [BlockSize]byte
+	nx    int
+	size  int
+}
+
+// New256 returns a new hash.Hash computing the Streebog-256 checksum
+func New256() hash.Hash {
+	d := &digest{size: Size256}
+	d.Reset()
+
+	return d
+}
+
+// New512 returns a new hash.Hash computing the Streebog-512 checksum
+func New512() hash.Hash {
+	d := &digest{size: Size512}
+	d.Reset()
+
+	return d
+}
+
+// Sum256 returns the Streebog-256 checksum of the data
+func Sum256(data []byte) [Size256]byte {
+	d := digest{size: Size256}
+	d.Reset()
+	d.Write(data)
+
+	var out [Size256]byte
+	d.Sum(out[:0])
+
+	return out
+}
+
+// Sum512 returns the Streebog-512 checksum of the data
+func Sum512(data []byte) [Size512]byte {
+	d := digest{size: Size512}
+	d.Reset()
+	d.Write(data)
+
+	var out [Size512]byte
+	d.Sum(out[:0])
+
+	return out
+}
+
+func (d *digest) Reset() {
+	// IV is 0^512 for Streebog-512 and (00000001)^64 for Streebog-256
+	var iv uint64
+	if Size256 == d.size {
+		iv = 0x0101010101010101
+	}
+	for i := range d.h {
+		d.h[i] = iv
+	}
+
+	d.n = [8]uint64{}
+	d.sigma = [8]uint64{}
+	d.nx = 0
+}
+
+func (d *digest) Size() int { return d.size }
+
+func (d *digest) BlockSize() int { return BlockSize }
+
+func (d *digest) Write(p []byte) (int, error) {
+	n := len(p)
+
+	if d.nx > 0 {
+		m := copy(d.x[d.nx:], p)
+		d.nx += m
+		if BlockSize == d.nx {
+			d.block(d.x[:], BlockSize)
+			d.nx = 0
+		}
+		p = p[m:]
+	}
+
+	for len(p) >= BlockSize {
+		d.block(p[:BlockSize], BlockSize)
+		p = p[BlockSize:]
+	}
+
+	if len(p) > 0 {
+		d.nx = copy(d.x[:], p)
+	}
+
+	return n, nil
+}
+
+// Sum appends the checksum to in without changing the underlying state
+func (d *digest) Sum(in []byte) []byte {
+	dd := *d
+
+	// pad the last block as 0^(511-|M|)||1||M in big endian
+	var last [BlockSize]byte
+	copy(last[:], dd.x[:dd.nx])
+	last[dd.nx] = 0x01
+	dd.block(last[:], dd.nx)
+
+	// h = g_0(h,N), h = g_0(h,sigma)
+	var zero [8]uint64
+	dd.h = g(&zero, &dd.h, &dd.n)
+	dd.h = g(&zero, &dd.h, &dd.sigma)
+
+	var out [Size512]byte
+	for i, v := range dd.h {
+		binary.LittleEndian.PutUint64(out[i*8:], v)
+	}
+
+	// Streebog-256 takes the most significant half
+	return append(in, out[Size512-dd.size:]...)
+}
+
+// block compresses the 64-byte block m carrying msgLen bytes of the message
+func (d *digest) block(m []byte, msgLen int) {
+	var mm [8]uint64
+	for i := range mm {
+		mm[i] = binary.LittleEndian.Uint64(m[i*8:])
+	}
+
+	d.h = g(&d.n, &d.h, &mm)
+
+	var bitLen [8]uint64
+	bitLen[0] = uint64(msgLen) << 3
+	add512(&d.n, &bitLen)
+	add512(&d.sigma, &mm)
+}
+
+// g is the compression function g_N(h,m) = E(LPS(h xor N),m) xor h xor m
+func g(n, h, m *[8]uint64) [8]uint64 {
+	var k, state [8]uint64
+	for i := range k {
+		k[i] = h[i] ^ n[i]
+	}
+	k = transform(&k)
+
+	// E(K,m) = X[K13]LPSX[K12]...LPSX[K1](m)
+	for i := range state {
+		state[i] = m[i] ^ k[i]
+	}
+	for r := 0; r < 12; r++ {
+		state = transform(&state)
+
+		// K_{r+1} = LPS(K_r xor C_r)
+		for i := range k {
+			k[i] ^= c[r][i]
+		}
+		k = transform(&k)
+
+		for i := range state {
+			state[i] ^= k[i]
+		}
+	}
+
+	for i := range state {
+		state[i] ^= h[i] ^ m[i]
+	}
+
+	return state
+}
+
+// transform calculates LPS(x), where the transposition P places the j-th
+// byte of the i-th word into the i-th byte of the j-th word
+func transform(x *[8]uint64) [8]uint64 {
+	var out [8]uint64
+	for i := range out {
+		for j := 0; j < 8; j++ {
+			out[i] ^= lps[j][byte(x[j]>>uint(8*i))]
+		}
+	}
+
+	return out
+}
+
+// add512 sets x to x+y mod 2^512
+func add512(x, y *[8]uint64) {
+	var carry uint64
+	for i := range x {
+		x[i], carry = bits.Add64(x[i], y[i], carry)
+	}
+}
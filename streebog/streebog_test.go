@@ -0,0 +1,101 @@
+package streebog_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"hash"
+	"strings"
+	"testing"
+
+	"github.com/sammy00/crypto/streebog"
+)
+
+// m1 and m2 are the examples of [RFC6986], where m2 is the byte-reversed
+// form of the one printed in the RFC
+var (
+	m1 = []byte("012345678901234567890123456789012345678901234567890123456789012")
+	m2 = mustHex("d1e520e2e5f2f0e82c20d1f2f0e8e1eee6e820e2edf3f6e82c20e2e5fef2fa20f120eceef0ff20f1f2f0e5ebe0ece820ede020f5f0e0e1f0fbff20efebfaeafb20c8e3eef0e5e2fb")
+)
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if nil != err {
+		panic(err)
+	}
+
+	return b
+}
+
+func TestStreebog(t *testing.T) {
+	testCases := []struct {
+		msg          []byte
+		sum256       string
+		sum512       string
+		streamChunks int
+	}{
+		{
+			m1,
+			"9d151eefd8590b89daa6ba6cb74af9275dd051026bb149a452fd84e5e57b5500",
+			"1b54d01a4af5b9d5cc3d86d68d285462b19abc2475222f35c085122be4ba1ffa00ad30f8767b3a82384c6574f024c311e2a481332b08ef7f41797891c1646f48",
+			7,
+		},
+		{
+			m2,
+			"9dd2fe4e90409e5da87f53976d7405b0c0cac628fc669a741d50063c557e8f50",
+			"1e88e62226bfca6f9994f1f2d51569e0daf8475a3b0fe61a5300eee46d961376035fe83549ada2b8620fcd7c496ce5b33f0cb9dddc2b6460143b03dabac9fb28",
+			5,
+		},
+		// the ones below are cross-checked against libgcrypt
+		{
+			nil,
+			"3f539a213e97c802cc229d474c6aa32a825a360b2a933a949fd925208d9ce1bb",
+			"8e945da209aa869f0455928529bcae4679e9873ab707b55315f56ceb98bef0a7362f715528356ee83cda5f2aac4c6ad2ba3a715c1bcd81cb8e9f90bf4c1c1a8a",
+			1,
+		},
+		{
+			[]byte(strings.Repeat("a", 64)),
+			"c2ce0969b6e468445ecfaed89f614178f89cc37ab59523528a58745007f33ab2",
+			"613852076ca11156cf7d00f4feef0d5e3198e638f8e20eb02da2f5f7dca5b62dd9fb88e22e825f727ed6f25e4145dc868d0ef41e3e451e34b780e5547ade0d43",
+			3,
+		},
+		{
+			[]byte(strings.Repeat("abc", 100)),
+			"050126f16b6d4d5e0dac90de7faaa971408e8eae2ead466197bbfc35074f9f60",
+			"9275c64b6c2d5819447fd10e280e142a7a0348ebc40bfa17c1849476049dd213a0d524b76bf05a8e27936fcc55d4238d6f28dd087b49a7b8b297808892224164",
+			13,
+		},
+	}
+
+	for i, c := range testCases {
+		want256, want512 := mustHex(c.sum256), mustHex(c.sum512)
+
+		if got := streebog.Sum256(c.msg); !bytes.Equal(got[:], want256) {
+			t.Errorf("#%d: invalid Streebog-256: got %x, want %x", i, got, want256)
+		}
+		if got := streebog.Sum512(c.msg); !bytes.Equal(got[:], want512) {
+			t.Errorf("#%d: invalid Streebog-512: got %x, want %x", i, got, want512)
+		}
+
+		for _, h := range []struct {
+			h    hash.Hash
+			want []byte
+		}{{streebog.New256(), want256}, {streebog.New512(), want512}} {
+			for j := 0; j < len(c.msg); j += c.streamChunks {
+				end := j + c.streamChunks
+				if end > len(c.msg) {
+					end = len(c.msg)
+				}
+				h.h.Write(c.msg[j:end])
+			}
+
+			if got := h.h.Sum(nil); !bytes.Equal(got, h.want) {
+				t.Errorf("#%d: invalid streamed digest: got %x, want %x", i, got, h.want)
+			}
+
+			// Sum shouldn't change the state
+			if got := h.h.Sum(nil); !bytes.Equal(got, h.want) {
+				t.Errorf("#%d: Sum changes the state", i)
+			}
+		}
+	}
+}